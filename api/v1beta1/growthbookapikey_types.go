@@ -0,0 +1,117 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RotateAnnotation triggers the rotation of an api key if its value changes
+const RotateAnnotation = "growthbook.infra.doodle.com/rotate"
+
+// APIKeyRole defines the permissions of an organization api key
+type APIKeyRole string
+
+var (
+	// APIKeyRoleAdmin grants full access to the organization
+	APIKeyRoleAdmin APIKeyRole = "admin"
+	// APIKeyRoleReadonly grants read-only access to the organization
+	APIKeyRoleReadonly APIKeyRole = "readonly"
+)
+
+// GrowthbookAPIKeySpec defines the desired state of GrowthbookAPIKey
+type GrowthbookAPIKeySpec struct {
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Role defines the permissions of an organization api key, personal access tokens inherit the permissions of the user
+	// +kubebuilder:validation:Enum=admin;readonly
+	// +kubebuilder:default:=readonly
+	Role APIKeyRole `json:"role,omitempty"`
+
+	// User references a GrowthbookUser by its resource name, the key is created as personal access token of the user if set
+	User string `json:"user,omitempty"`
+
+	// Environment limits the key to an environment of the organization
+	Environment string `json:"environment,omitempty"`
+
+	// Project references a GrowthbookProject by its resource name and limits the key to the project
+	Project string `json:"project,omitempty"`
+
+	// TokenSecret is the secret the generated key is written to, the secret is created if it does not exist
+	TokenSecret *TokenSecretReference `json:"tokenSecret"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GetID returns the api key ID which is the resource name if not overwritten by spec.ID
+func (k *GrowthbookAPIKey) GetID() string {
+	if k.Spec.ID == "" {
+		return k.Name
+	}
+
+	return k.Spec.ID
+}
+
+// GrowthbookAPIKeyStatus defines the observed state of GrowthbookAPIKey
+type GrowthbookAPIKeyStatus struct {
+	DocumentStatus `json:",inline"`
+
+	// LastRotation is the value of the rotate annotation the key has been rotated for the last time
+	LastRotation string `json:"lastRotation,omitempty"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookAPIKey) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookAPIKey) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookAPIKey is the Schema for the GrowthbookAPIKeys API
+type GrowthbookAPIKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookAPIKeySpec   `json:"spec,omitempty"`
+	Status GrowthbookAPIKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookAPIKeyList contains a list of GrowthbookAPIKey
+type GrowthbookAPIKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookAPIKey `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookAPIKey{}, &GrowthbookAPIKeyList{})
+}
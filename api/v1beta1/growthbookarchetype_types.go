@@ -0,0 +1,104 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookArchetypeSpec defines the desired state of GrowthbookArchetype
+type GrowthbookArchetypeSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Owner is the growthbook user id of the owner of the archetype
+	Owner string `json:"owner,omitempty"`
+
+	// Public makes the archetype visible to all members of the organization, otherwise it is only visible to its owner
+	Public bool `json:"public,omitempty"`
+
+	// Attributes is a JSON object of the user attributes the archetype previews features with
+	// +kubebuilder:default:="{}"
+	Attributes string `json:"attributes,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GetID returns the archetype ID which is the resource name if not overwritten by spec.ID
+func (a *GrowthbookArchetype) GetID() string {
+	if a.Spec.ID == "" {
+		return a.Name
+	}
+
+	return a.Spec.ID
+}
+
+// GetName returns the archetype name which is the resource name if not overwritten by spec.Name
+func (a *GrowthbookArchetype) GetName() string {
+	if a.Spec.Name == "" {
+		return a.Name
+	}
+
+	return a.Spec.Name
+}
+
+// GrowthbookArchetypeStatus defines the observed state of GrowthbookArchetype
+type GrowthbookArchetypeStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookArchetype) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookArchetype) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookArchetype is the Schema for the GrowthbookArchetypes API
+type GrowthbookArchetype struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookArchetypeSpec   `json:"spec,omitempty"`
+	Status GrowthbookArchetypeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookArchetypeList contains a list of GrowthbookArchetype
+type GrowthbookArchetypeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookArchetype `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookArchetype{}, &GrowthbookArchetypeList{})
+}
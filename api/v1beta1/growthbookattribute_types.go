@@ -0,0 +1,114 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookAttributeSpec defines the desired state of GrowthbookAttribute
+type GrowthbookAttributeSpec struct {
+	// Property is the attribute name used in targeting conditions, defaults to the resource name
+	Property    string `json:"property,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// +kubebuilder:default:=string
+	// +kubebuilder:validation:Enum=boolean;number;string;enum;secureString;number[];string[];secureString[]
+	Datatype AttributeDatatype `json:"datatype,omitempty"`
+
+	// Enum holds the allowed values of enum attributes
+	Enum []string `json:"enum,omitempty"`
+
+	// HashAttribute marks the attribute as an identifier which can be used to assign variations
+	HashAttribute bool `json:"hashAttribute,omitempty"`
+
+	Archived bool `json:"archived,omitempty"`
+
+	// Projects scopes the attribute to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan;Archive
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// AttributeDatatype defines the data type of an attribute
+type AttributeDatatype string
+
+var (
+	AttributeDatatypeBoolean           AttributeDatatype = "boolean"
+	AttributeDatatypeNumber            AttributeDatatype = "number"
+	AttributeDatatypeString            AttributeDatatype = "string"
+	AttributeDatatypeEnum              AttributeDatatype = "enum"
+	AttributeDatatypeSecureString      AttributeDatatype = "secureString"
+	AttributeDatatypeNumberArray       AttributeDatatype = "number[]"
+	AttributeDatatypeStringArray       AttributeDatatype = "string[]"
+	AttributeDatatypeSecureStringArray AttributeDatatype = "secureString[]"
+)
+
+// GetProperty returns the attribute property which is the resource name if not overwritten by spec.Property
+func (a *GrowthbookAttribute) GetProperty() string {
+	if a.Spec.Property == "" {
+		return a.Name
+	}
+
+	return a.Spec.Property
+}
+
+// GrowthbookAttributeStatus defines the observed state of GrowthbookAttribute
+type GrowthbookAttributeStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookAttribute) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookAttribute) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookAttribute is the Schema for the GrowthbookAttributes API
+type GrowthbookAttribute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookAttributeSpec   `json:"spec,omitempty"`
+	Status GrowthbookAttributeStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookAttributeList contains a list of GrowthbookAttribute
+type GrowthbookAttributeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookAttribute `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookAttribute{}, &GrowthbookAttributeList{})
+}
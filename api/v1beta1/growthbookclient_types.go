@@ -25,14 +25,19 @@ type GrowthbookClientSpec struct {
 	Languages []string `json:"languages,omitempty"`
 	Name      string   `json:"name,omitempty"`
 	// +kubebuilder:default:=dev
-	Environment              string                `json:"environment,omitempty"`
-	EncryptPayload           bool                  `json:"encryptPayload,omitempty"`
+	Environment    string `json:"environment,omitempty"`
+	EncryptPayload bool   `json:"encryptPayload,omitempty"`
+	// Project references a GrowthbookProject by its resource name
 	Project                  string                `json:"project,omitempty"`
 	IncludeVisualExperiments bool                  `json:"includeVisualExperiments,omitempty"`
 	IncludeDraftExperiments  bool                  `json:"includeDraftExperiments,omitempty"`
 	IncludeExperimentNames   bool                  `json:"includeExperimentNames,omitempty"`
 	ID                       string                `json:"id,omitempty"`
 	TokenSecret              *TokenSecretReference `json:"tokenSecret"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // GetID returns the client ID which is the resource name if not overwritten by spec.ID
@@ -62,7 +67,26 @@ type TokenSecretReference struct {
 	TokenField string `json:"tokenField,omitempty"`
 }
 
+// GrowthbookClientStatus defines the observed state of GrowthbookClient
+type GrowthbookClientStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookClient) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookClient) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 
 // GrowthbookClient is the Schema for the GrowthbookClients API
@@ -70,7 +94,8 @@ type GrowthbookClient struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GrowthbookClientSpec `json:"spec,omitempty"`
+	Spec   GrowthbookClientSpec   `json:"spec,omitempty"`
+	Status GrowthbookClientStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
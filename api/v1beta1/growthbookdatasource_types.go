@@ -0,0 +1,140 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookDataSourceSpec defines the desired state of GrowthbookDataSource
+type GrowthbookDataSourceSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is the type of the data warehouse or analytics tool
+	// +kubebuilder:validation:Enum=redshift;athena;google_analytics;snowflake;postgres;mysql;mssql;bigquery;clickhouse;presto;databricks;mixpanel;vertica
+	// +required
+	Type string `json:"type"`
+
+	// Projects scopes the data source to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+
+	// Params is a JSON object of the connection parameters which are not sensitive, for instance the host and the port
+	// +kubebuilder:default:="{}"
+	Params string `json:"params,omitempty"`
+
+	// ParamsSecret references a secret whose keys are added to the connection parameters, for instance the user and the password
+	ParamsSecret *ParamsSecretReference `json:"paramsSecret,omitempty"`
+
+	// Settings is a JSON object of the data source settings, for instance the user id types
+	Settings string `json:"settings,omitempty"`
+
+	// Queries defines the exposure queries of the data source, they replace the exposure queries of the settings
+	Queries []DataSourceExposureQuery `json:"queries,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// ParamsSecretReference is a named reference to a secret which contains connection parameters
+type ParamsSecretReference struct {
+	// Name referrs to the name of the secret, must be located whithin the same namespace
+	Name string `json:"name"`
+}
+
+// DataSourceExposureQuery defines a query which returns the experiment exposures of users
+type DataSourceExposureQuery struct {
+	// +required
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// UserIDType is the type of the user identifier returned by the query
+	// +kubebuilder:default:=user_id
+	UserIDType string `json:"userIdType,omitempty"`
+
+	// +required
+	Query string `json:"query"`
+
+	// Dimensions lists the additional columns of the query which can be used to drill down the results
+	Dimensions []string `json:"dimensions,omitempty"`
+	HasNameCol bool     `json:"hasNameCol,omitempty"`
+}
+
+// GetID returns the data source ID which is the resource name if not overwritten by spec.ID
+func (d *GrowthbookDataSource) GetID() string {
+	if d.Spec.ID == "" {
+		return d.Name
+	}
+
+	return d.Spec.ID
+}
+
+// GetName returns the data source name which is the resource name if not overwritten by spec.Name
+func (d *GrowthbookDataSource) GetName() string {
+	if d.Spec.Name == "" {
+		return d.Name
+	}
+
+	return d.Spec.Name
+}
+
+// GrowthbookDataSourceStatus defines the observed state of GrowthbookDataSource
+type GrowthbookDataSourceStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookDataSource) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookDataSource) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookDataSource is the Schema for the GrowthbookDataSources API
+type GrowthbookDataSource struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookDataSourceSpec   `json:"spec,omitempty"`
+	Status GrowthbookDataSourceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookDataSourceList contains a list of GrowthbookDataSource
+type GrowthbookDataSourceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookDataSource `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookDataSource{}, &GrowthbookDataSourceList{})
+}
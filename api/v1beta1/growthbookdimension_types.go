@@ -0,0 +1,109 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookDimensionSpec defines the desired state of GrowthbookDimension
+type GrowthbookDimensionSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Owner is the growthbook user id of the owner of the dimension
+	Owner string `json:"owner,omitempty"`
+
+	// DataSource is the name of the GrowthbookDataSource the dimension is queried from
+	// +required
+	DataSource string `json:"dataSource"`
+
+	// UserIDType is the type of the user identifier returned by the SQL query
+	// +kubebuilder:default:=user_id
+	UserIDType string `json:"userIdType,omitempty"`
+
+	// SQL is the query which returns the dimension value of every user
+	// +required
+	SQL string `json:"sql"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GetID returns the dimension ID which is the resource name if not overwritten by spec.ID
+func (d *GrowthbookDimension) GetID() string {
+	if d.Spec.ID == "" {
+		return d.Name
+	}
+
+	return d.Spec.ID
+}
+
+// GetName returns the dimension name which is the resource name if not overwritten by spec.Name
+func (d *GrowthbookDimension) GetName() string {
+	if d.Spec.Name == "" {
+		return d.Name
+	}
+
+	return d.Spec.Name
+}
+
+// GrowthbookDimensionStatus defines the observed state of GrowthbookDimension
+type GrowthbookDimensionStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookDimension) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookDimension) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookDimension is the Schema for the GrowthbookDimensions API
+type GrowthbookDimension struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookDimensionSpec   `json:"spec,omitempty"`
+	Status GrowthbookDimensionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookDimensionList contains a list of GrowthbookDimension
+type GrowthbookDimensionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookDimension `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookDimension{}, &GrowthbookDimensionList{})
+}
@@ -0,0 +1,157 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookExperimentSpec defines the desired state of GrowthbookExperiment
+type GrowthbookExperimentSpec struct {
+	Name        string   `json:"name,omitempty"`
+	ID          string   `json:"id,omitempty"`
+	Owner       string   `json:"owner,omitempty"`
+	Description string   `json:"description,omitempty"`
+	Hypothesis  string   `json:"hypothesis,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+
+	// Project references a GrowthbookProject by its resource name
+	Project string `json:"project,omitempty"`
+
+	// TrackingKey is the key used to track the experiment, the experiment id is used if not set
+	TrackingKey string `json:"trackingKey,omitempty"`
+
+	// HashAttribute is the user attribute used to assign variations
+	// +kubebuilder:default:=id
+	HashAttribute     string `json:"hashAttribute,omitempty"`
+	FallbackAttribute string `json:"fallbackAttribute,omitempty"`
+
+	// +kubebuilder:default:=draft
+	// +kubebuilder:validation:Enum=draft;running;stopped
+	Status ExperimentStatus `json:"status,omitempty"`
+
+	// +kubebuilder:validation:MinItems=2
+	Variations []ExperimentVariation `json:"variations,omitempty"`
+
+	Phases []ExperimentPhase `json:"phases,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance.
+	// Archive keeps the experiment in growthbook but hides it from the experiment list.
+	// +kubebuilder:validation:Enum=Delete;Orphan;Archive
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// ExperimentStatus defines the status of an experiment
+type ExperimentStatus string
+
+var (
+	ExperimentStatusDraft   ExperimentStatus = "draft"
+	ExperimentStatusRunning ExperimentStatus = "running"
+	ExperimentStatusStopped ExperimentStatus = "stopped"
+)
+
+// ExperimentVariation defines a variation of an experiment
+type ExperimentVariation struct {
+	// ID of the variation. If not set an ID is derived from the experiment and the variation key.
+	ID string `json:"id,omitempty"`
+
+	// Key is the value assigned to users, the position of the variation is used if not set
+	Key         string `json:"key,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// ExperimentPhase defines a phase of an experiment
+type ExperimentPhase struct {
+	Name        string       `json:"name,omitempty"`
+	Reason      string       `json:"reason,omitempty"`
+	DateStarted *metav1.Time `json:"dateStarted,omitempty"`
+	DateEnded   *metav1.Time `json:"dateEnded,omitempty"`
+
+	// Coverage is the percentage of users included in the experiment, between 0 and 1
+	// +kubebuilder:default:="1"
+	Coverage  string `json:"coverage,omitempty"`
+	Condition string `json:"condition,omitempty"`
+
+	// VariationWeights defines the traffic split between the variations, equal weights are used if not set
+	VariationWeights []string              `json:"variationWeights,omitempty"`
+	SavedGroups      []SavedGroupTargeting `json:"savedGroups,omitempty"`
+	Namespace        *NamespaceValue       `json:"namespace,omitempty"`
+	Seed             string                `json:"seed,omitempty"`
+}
+
+// GetID returns the experiment ID which is the resource name if not overwritten by spec.ID
+func (e *GrowthbookExperiment) GetID() string {
+	if e.Spec.ID == "" {
+		return e.Name
+	}
+
+	return e.Spec.ID
+}
+
+// GetName returns the experiment name which is the resource name if not overwritten by spec.Name
+func (e *GrowthbookExperiment) GetName() string {
+	if e.Spec.Name == "" {
+		return e.Name
+	}
+
+	return e.Spec.Name
+}
+
+// GrowthbookExperimentStatus defines the observed state of GrowthbookExperiment
+type GrowthbookExperimentStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookExperiment) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookExperiment) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookExperiment is the Schema for the GrowthbookExperiments API
+type GrowthbookExperiment struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookExperimentSpec   `json:"spec,omitempty"`
+	Status GrowthbookExperimentStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookExperimentList contains a list of GrowthbookExperiment
+type GrowthbookExperimentList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookExperiment `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookExperiment{}, &GrowthbookExperimentList{})
+}
@@ -0,0 +1,147 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookFactMetricSpec defines the desired state of GrowthbookFactMetric
+type GrowthbookFactMetricSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Owner is the growthbook user id of the owner of the fact metric
+	Owner string `json:"owner,omitempty"`
+
+	// DataSource is the name of the GrowthbookDataSource of the referenced fact tables
+	// +required
+	DataSource string `json:"dataSource"`
+
+	// Projects scopes the fact metric to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	// MetricType defines how the fact values are aggregated, ratio metrics require a denominator
+	// +kubebuilder:validation:Enum=proportion;mean;ratio
+	// +required
+	MetricType FactMetricType `json:"metricType"`
+
+	// Inverse marks metrics where a lower value is better, for instance the bounce rate
+	Inverse bool `json:"inverse,omitempty"`
+
+	// +required
+	Numerator FactColumnReference `json:"numerator"`
+
+	// Denominator is only used by ratio metrics
+	Denominator *FactColumnReference `json:"denominator,omitempty"`
+
+	// Window restricts the metric to the events within a window after the experiment exposure
+	Window *MetricWindow `json:"window,omitempty"`
+
+	// Cap limits the metric value per user to reduce the impact of outliers
+	Cap *MetricCap `json:"cap,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+type FactMetricType string
+
+var (
+	FactMetricTypeProportion FactMetricType = "proportion"
+	FactMetricTypeMean       FactMetricType = "mean"
+	FactMetricTypeRatio      FactMetricType = "ratio"
+)
+
+// FactColumnReference references a column of a GrowthbookFactTable
+type FactColumnReference struct {
+	// FactTable is the name of the GrowthbookFactTable
+	// +required
+	FactTable string `json:"factTable"`
+
+	// Column is a column of the fact table, $$count counts the rows and $$distinctUsers counts the users
+	// +kubebuilder:default:=$$count
+	Column string `json:"column,omitempty"`
+
+	// Filters lists ids of fact table filters the rows are restricted with
+	Filters []string `json:"filters,omitempty"`
+}
+
+// GetID returns the fact metric ID which is the resource name if not overwritten by spec.ID
+func (m *GrowthbookFactMetric) GetID() string {
+	if m.Spec.ID == "" {
+		return m.Name
+	}
+
+	return m.Spec.ID
+}
+
+// GetName returns the fact metric name which is the resource name if not overwritten by spec.Name
+func (m *GrowthbookFactMetric) GetName() string {
+	if m.Spec.Name == "" {
+		return m.Name
+	}
+
+	return m.Spec.Name
+}
+
+// GrowthbookFactMetricStatus defines the observed state of GrowthbookFactMetric
+type GrowthbookFactMetricStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookFactMetric) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookFactMetric) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookFactMetric is the Schema for the GrowthbookFactMetrics API
+type GrowthbookFactMetric struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookFactMetricSpec   `json:"spec,omitempty"`
+	Status GrowthbookFactMetricStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookFactMetricList contains a list of GrowthbookFactMetric
+type GrowthbookFactMetricList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookFactMetric `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookFactMetric{}, &GrowthbookFactMetricList{})
+}
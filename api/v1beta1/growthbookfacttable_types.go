@@ -0,0 +1,149 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookFactTableSpec defines the desired state of GrowthbookFactTable
+type GrowthbookFactTableSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Owner is the growthbook user id of the owner of the fact table
+	Owner string `json:"owner,omitempty"`
+
+	// DataSource is the name of the GrowthbookDataSource the fact table is queried from
+	// +required
+	DataSource string `json:"dataSource"`
+
+	// Projects scopes the fact table to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	// UserIDTypes lists the user id types returned by the SQL query
+	UserIDTypes []string `json:"userIdTypes,omitempty"`
+
+	// SQL is the query which returns the facts, one row per event
+	// +required
+	SQL string `json:"sql"`
+
+	// EventName is the name of the event if the data source is event based
+	EventName string `json:"eventName,omitempty"`
+
+	// Columns describes the columns returned by the SQL query.
+	// Growthbook detects the columns by itself if they are not declared.
+	Columns []FactTableColumn `json:"columns,omitempty"`
+
+	// Filters are reusable conditions fact metrics can be restricted with
+	Filters []FactTableFilter `json:"filters,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// FactTableColumn describes a column of a fact table
+type FactTableColumn struct {
+	// +required
+	Column string `json:"column"`
+	Name   string `json:"name,omitempty"`
+
+	// +kubebuilder:validation:Enum=number;string;date;boolean;other
+	// +kubebuilder:default:=string
+	Datatype string `json:"datatype,omitempty"`
+
+	// NumberFormat defines how numeric values are displayed
+	// +kubebuilder:validation:Enum=currency;time:seconds;memory:bytes;memory:kilobytes
+	NumberFormat string `json:"numberFormat,omitempty"`
+}
+
+// FactTableFilter is a named SQL condition of a fact table
+type FactTableFilter struct {
+	// +required
+	ID          string `json:"id"`
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Value is the SQL condition, for instance `event_name = 'purchase'`
+	// +required
+	Value string `json:"value"`
+}
+
+// GetID returns the fact table ID which is the resource name if not overwritten by spec.ID
+func (t *GrowthbookFactTable) GetID() string {
+	if t.Spec.ID == "" {
+		return t.Name
+	}
+
+	return t.Spec.ID
+}
+
+// GetName returns the fact table name which is the resource name if not overwritten by spec.Name
+func (t *GrowthbookFactTable) GetName() string {
+	if t.Spec.Name == "" {
+		return t.Name
+	}
+
+	return t.Spec.Name
+}
+
+// GrowthbookFactTableStatus defines the observed state of GrowthbookFactTable
+type GrowthbookFactTableStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookFactTable) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookFactTable) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookFactTable is the Schema for the GrowthbookFactTables API
+type GrowthbookFactTable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookFactTableSpec   `json:"spec,omitempty"`
+	Status GrowthbookFactTableStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookFactTableList contains a list of GrowthbookFactTable
+type GrowthbookFactTableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookFactTable `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookFactTable{}, &GrowthbookFactTableList{})
+}
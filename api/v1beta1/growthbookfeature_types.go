@@ -27,8 +27,22 @@ type GrowthbookFeatureSpec struct {
 	Tags         []string         `json:"tags,omitempty"`
 	DefaultValue string           `json:"defaultValue,omitempty"`
 	ValueType    FeatureValueType `json:"valueType,omitempty"`
+	// Project references a GrowthbookProject by its resource name
+	Project string `json:"project,omitempty"`
 	// +kubebuilder:default:={{name: dev, enabled: true}}
 	Environments []Environment `json:"environments,omitempty"`
+
+	// RuleMergeStrategy defines how rules from the spec are combined with rules created in the growthbook UI.
+	// Replace removes all rules not defined in the spec, Append adds the spec rules after
+	// the UI rules and Prepend before them.
+	// +kubebuilder:default:=Append
+	// +kubebuilder:validation:Enum=Replace;Append;Prepend
+	RuleMergeStrategy RuleMergeStrategy `json:"ruleMergeStrategy,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance.
+	// Archive keeps the feature in growthbook but it won't be served to SDKs anymore.
+	// +kubebuilder:validation:Enum=Delete;Orphan;Archive
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // +kubebuilder:validation:Enum=boolean;string;number;json
@@ -41,6 +55,14 @@ var (
 	FeatureValueTypeJSON    FeatureValueType = "json"
 )
 
+type RuleMergeStrategy string
+
+var (
+	RuleMergeStrategyReplace RuleMergeStrategy = "Replace"
+	RuleMergeStrategyAppend  RuleMergeStrategy = "Append"
+	RuleMergeStrategyPrepend RuleMergeStrategy = "Prepend"
+)
+
 // +kubebuilder:validation:Enum=all;none;any
 type SavedGroupTargetingMatch string
 
@@ -61,28 +83,34 @@ var (
 )
 
 type FeatureRule struct {
-	Type                   FeatureRuleType          `json:"type,omitempty"`
-	Description            string                   `json:"description,omitempty"`
-	Condition              string                   `json:"condition,omitempty"`
-	Enabled                bool                     `json:"enabled,omitempty"`
-	ScheduleRules          []ScheduleRule           `json:"scheduleRules,omitempty"`
-	SavedGroups            []SavedGroupTargeting    `json:"savedGroups,omitempty"`
-	Prerequisites          []FeaturePrerequisite    `json:"prerequisites,omitempty"`
-	Value                  string                   `json:"value,omitempty"`
-	Coverage               string                   `json:"coverage,omitempty"`
-	HashAttribute          string                   `json:"hashAttribute,omitempty"`
-	TrackingKey            string                   `json:"trackingKey,omitempty"`
-	FallbackAttribute      *string                  `json:"fallbackAttribute,omitempty"`
-	DisableStickyBucketing *bool                    `json:"disableStickyBucketing,omitempty"`
-	BucketVersion          *string                  `json:"bucketVersion,omitempty"`
-	MinBucketVersion       *string                  `json:"minBucketVersion,omitempty"`
-	Namespace              *NamespaceValue          `json:"namespace,omitempty"`
-	Values                 []ExperimentValue        `json:"values,omitempty"`
-	ExperimentID           string                   `json:"experimentId,omitempty"`
-	Variations             []ExperimentRefVariation `json:"variations,omitempty"`
+	// ID of the rule. If not set an ID is derived from the feature, the environment and the position of the rule.
+	ID                     string                `json:"id,omitempty"`
+	Type                   FeatureRuleType       `json:"type,omitempty"`
+	Description            string                `json:"description,omitempty"`
+	Condition              string                `json:"condition,omitempty"`
+	Enabled                bool                  `json:"enabled,omitempty"`
+	ScheduleRules          []ScheduleRule        `json:"scheduleRules,omitempty"`
+	SavedGroups            []SavedGroupTargeting `json:"savedGroups,omitempty"`
+	Prerequisites          []FeaturePrerequisite `json:"prerequisites,omitempty"`
+	Value                  string                `json:"value,omitempty"`
+	Coverage               string                `json:"coverage,omitempty"`
+	HashAttribute          string                `json:"hashAttribute,omitempty"`
+	TrackingKey            string                `json:"trackingKey,omitempty"`
+	FallbackAttribute      *string               `json:"fallbackAttribute,omitempty"`
+	DisableStickyBucketing *bool                 `json:"disableStickyBucketing,omitempty"`
+	BucketVersion          *string               `json:"bucketVersion,omitempty"`
+	MinBucketVersion       *string               `json:"minBucketVersion,omitempty"`
+	Namespace              *NamespaceValue       `json:"namespace,omitempty"`
+	Values                 []ExperimentValue     `json:"values,omitempty"`
+	// ExperimentID references a GrowthbookExperiment by its resource name.
+	// Values which do not match any GrowthbookExperiment of the organization are used as experiment ids as they are.
+	ExperimentID string                   `json:"experimentId,omitempty"`
+	Variations   []ExperimentRefVariation `json:"variations,omitempty"`
 }
 
 type ExperimentRefVariation struct {
+	// VariationId references a variation of the GrowthbookExperiment by its name or key.
+	// Values which do not match any variation are used as variation ids as they are.
 	VariationId string `json:"variationId,omitempty"`
 	Value       string `json:"value,omitempty"`
 }
@@ -99,10 +127,14 @@ type ScheduleRule struct {
 
 type SavedGroupTargeting struct {
 	Match SavedGroupTargetingMatch `json:"match,omitempty"`
-	IDs   []string                 `json:"ids,omitempty"`
+	// IDs references saved groups by their GrowthbookSavedGroup resource name.
+	// Values which do not match any GrowthbookSavedGroup of the organization are used as saved group ids as they are.
+	IDs []string `json:"ids,omitempty"`
 }
 
 type ExperimentValue struct {
+	// ID of the variation. If not set an ID is derived from the rule ID and the position of the variation.
+	ID     string  `json:"id,omitempty"`
 	Value  string  `json:"value,omitempty"`
 	Weight string  `json:"weight,omitempty"`
 	Name   *string `json:"name,omitempty"`
@@ -130,7 +162,33 @@ type Environment struct {
 	Rules   []FeatureRule `json:"rules,omitempty"`
 }
 
+// GrowthbookFeatureStatus defines the observed state of GrowthbookFeature
+type GrowthbookFeatureStatus struct {
+	DocumentStatus `json:",inline"`
+
+	// UnknownAttributes lists the attributes used by conditions and hash attributes of the feature which are not declared in the attribute schema of the organization
+	UnknownAttributes []string `json:"unknownAttributes,omitempty"`
+
+	// ManagedRules lists the explicit ids of the rules applied from the spec.
+	// These rules are removed from growthbook once they are removed from the spec.
+	ManagedRules []string `json:"managedRules,omitempty"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookFeature) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookFeature) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 
 // GrowthbookFeature is the Schema for the GrowthbookFeatures API
@@ -138,7 +196,8 @@ type GrowthbookFeature struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GrowthbookFeatureSpec `json:"spec,omitempty"`
+	Spec   GrowthbookFeatureSpec   `json:"spec,omitempty"`
+	Status GrowthbookFeatureStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -25,13 +25,27 @@ type GrowthbookInstanceSpec struct {
 	// MongoDB settings
 	MongoDB GrowthbookInstanceMongoDB `json:"mongodb,omitempty"`
 
+	// APIHost is the address of the growthbook API, for example `http://growthbook:3100`.
+	// It is used to fetch the sdk payloads which are sent to sdk webhooks and the growthbook proxy after changes.
+	APIHost string `json:"apiHost,omitempty"`
+
+	// EncryptionKeySecret references the secret which holds the ENCRYPTION_KEY of the growthbook backend.
+	// It is required to write the connection parameters of data sources.
+	EncryptionKeySecret *EncryptionKeySecretReference `json:"encryptionKeySecret,omitempty"`
+
 	// Interval reconciliation
 	Interval *metav1.Duration `json:"interval,omitempty"`
 
-	// Prune
+	// Prune removes documents from growthbook if their resources are deleted.
+	// It only applies if neither the resource nor the instance define a deletionPolicy.
 	// +kubebuilder:validation:Required
 	Prune bool `json:"prune"`
 
+	// DeletionPolicy is the default deletion policy for all resources associated with this instance.
+	// Archive is supported by features, experiments and attributes, other resources are orphaned instead.
+	// +kubebuilder:validation:Enum=Delete;Orphan;Archive
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
 	// Timeout while reconciling the instance
 	// +kubebuilder:default:="5m"
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
@@ -41,8 +55,23 @@ type GrowthbookInstanceSpec struct {
 
 	// ResourceSelector defines a selector to select Growthbook resources associated with this instance
 	ResourceSelector *metav1.LabelSelector `json:"resourceSelector,omitempty"`
+
+	// DriftPolicy defines how changes made outside of kubernetes are handled.
+	// Revert overwrites the changes and reports them, Report only reports them and Ignore leaves them untouched.
+	// +kubebuilder:default:=Revert
+	// +kubebuilder:validation:Enum=Revert;Report;Ignore
+	DriftPolicy DriftPolicy `json:"driftPolicy,omitempty"`
 }
 
+// DriftPolicy defines how growthbook documents which have been changed outside of kubernetes are handled
+type DriftPolicy string
+
+var (
+	DriftPolicyRevert DriftPolicy = "Revert"
+	DriftPolicyReport DriftPolicy = "Report"
+	DriftPolicyIgnore DriftPolicy = "Ignore"
+)
+
 // GrowthbookInstanceMongoDB defines how to connect to the growthbook MongoDB
 type GrowthbookInstanceMongoDB struct {
 	// Address is a MongoDB comptaible URI `mongodb://xxx`
@@ -52,6 +81,15 @@ type GrowthbookInstanceMongoDB struct {
 	Secret *SecretReference `json:"rootSecret,omitempty"`
 }
 
+// EncryptionKeySecretReference is a named reference to a secret which contains the growthbook encryption key
+type EncryptionKeySecretReference struct {
+	// Name referrs to the name of the secret, must be located whithin the same namespace
+	Name string `json:"name"`
+
+	// +kubebuilder:default:=ENCRYPTION_KEY
+	KeyField string `json:"keyField,omitempty"`
+}
+
 // GrowthbookInstanceStatus defines the observed state of GrowthbookInstance
 type GrowthbookInstanceStatus struct {
 	// Conditions holds the conditions for the KeycloakRealm.
@@ -66,6 +104,12 @@ type GrowthbookInstanceStatus struct {
 
 	// SubResourceCatalog holds references to all sub resources including GrowthbookFeature and GrowthbookClient associated with this instance
 	SubResourceCatalog []ResourceReference `json:"subResourceCatalog,omitempty"`
+
+	// Inventory holds references to all growthbook documents which have been applied by this instance
+	Inventory []InventoryEntry `json:"inventory,omitempty"`
+
+	// DriftedResources holds all resources whose growthbook documents have been changed outside of kubernetes
+	DriftedResources []DriftedResource `json:"driftedResources,omitempty"`
 }
 
 // ResourceReference metadata to lookup another resource
@@ -75,6 +119,48 @@ type ResourceReference struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 }
 
+// InventoryEntry references a growthbook document and the resource it was applied from
+type InventoryEntry struct {
+	ResourceReference `json:",inline"`
+
+	// ID is the id of the growthbook document
+	ID string `json:"id,omitempty"`
+
+	// Organization is the growthbook organization the document belongs to
+	Organization string `json:"organization,omitempty"`
+
+	// Checksum of the document as it was rendered from the resource and the generation of the resource
+	Checksum string `json:"checksum,omitempty"`
+}
+
+// DriftedResource references a resource whose growthbook document differs from the desired state
+type DriftedResource struct {
+	ResourceReference `json:",inline"`
+
+	// Organization is the growthbook organization the document belongs to
+	Organization string `json:"organization,omitempty"`
+
+	// Fields which differ from the desired state
+	Fields []string `json:"fields,omitempty"`
+}
+
+// GetDeletionPolicy returns the deletion policy which applies to a resource with the given deletion policy
+func (in *GrowthbookInstance) GetDeletionPolicy(policy DeletionPolicy) DeletionPolicy {
+	if policy != "" {
+		return policy
+	}
+
+	if in.Spec.DeletionPolicy != "" {
+		return in.Spec.DeletionPolicy
+	}
+
+	if in.Spec.Prune {
+		return DeletionPolicyDelete
+	}
+
+	return DeletionPolicyOrphan
+}
+
 // GrowthbookInstanceNotReady
 func GrowthbookInstanceNotReady(clone GrowthbookInstance, reason, message string) GrowthbookInstance {
 	setResourceCondition(&clone, ReadyCondition, metav1.ConditionFalse, reason, message)
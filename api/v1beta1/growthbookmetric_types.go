@@ -0,0 +1,196 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookMetricSpec defines the desired state of GrowthbookMetric
+type GrowthbookMetricSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Owner is the growthbook user id of the owner of the metric
+	Owner string `json:"owner,omitempty"`
+
+	// DataSource is the name of the GrowthbookDataSource the metric is computed from
+	// +required
+	DataSource string `json:"dataSource"`
+
+	// Type defines how the metric values are aggregated per user
+	// +kubebuilder:validation:Enum=binomial;count;duration;revenue
+	// +required
+	Type MetricType `json:"type"`
+
+	// Projects scopes the metric to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	// Inverse marks metrics where a lower value is better, for instance the bounce rate
+	Inverse bool `json:"inverse,omitempty"`
+
+	// IgnoreNulls excludes users without any metric value
+	IgnoreNulls bool `json:"ignoreNulls,omitempty"`
+
+	// QueryFormat defines whether the metric is defined by a SQL query or by an event definition
+	// +kubebuilder:validation:Enum=sql;builder
+	// +kubebuilder:default:=sql
+	QueryFormat MetricQueryFormat `json:"queryFormat,omitempty"`
+
+	// SQL is the query which returns the metric values, required for the sql query format
+	SQL string `json:"sql,omitempty"`
+
+	// UserIDTypes lists the user id types returned by the SQL query
+	UserIDTypes []string `json:"userIdTypes,omitempty"`
+
+	// Table, Column and Conditions define the metric events for the builder query format
+	Table      string            `json:"table,omitempty"`
+	Column     string            `json:"column,omitempty"`
+	Conditions []MetricCondition `json:"conditions,omitempty"`
+
+	// Window restricts the metric to the events within a window after the experiment exposure
+	Window *MetricWindow `json:"window,omitempty"`
+
+	// Cap limits the metric value per user to reduce the impact of outliers
+	Cap *MetricCap `json:"cap,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+type MetricType string
+
+var (
+	MetricTypeBinomial MetricType = "binomial"
+	MetricTypeCount    MetricType = "count"
+	MetricTypeDuration MetricType = "duration"
+	MetricTypeRevenue  MetricType = "revenue"
+)
+
+type MetricQueryFormat string
+
+var (
+	MetricQueryFormatSQL     MetricQueryFormat = "sql"
+	MetricQueryFormatBuilder MetricQueryFormat = "builder"
+)
+
+// MetricCondition filters the events of a metric defined using the builder query format
+type MetricCondition struct {
+	// +required
+	Column string `json:"column"`
+
+	// +kubebuilder:validation:Enum="=";"!=";"~";"!~";"<";">";"<=";">="
+	// +kubebuilder:default:="="
+	Operator string `json:"operator,omitempty"`
+	Value    string `json:"value,omitempty"`
+}
+
+// MetricWindow defines the window after the experiment exposure in which metric events are considered
+type MetricWindow struct {
+	// Type is either a conversion window starting at the exposure or a lookback window ending at the end of the experiment
+	// +kubebuilder:validation:Enum=conversion;lookback
+	// +kubebuilder:default:=conversion
+	Type string `json:"type,omitempty"`
+
+	// DelayHours delays the start of the window after the exposure
+	DelayHours int64 `json:"delayHours,omitempty"`
+
+	// +kubebuilder:default:=72
+	// +kubebuilder:validation:Minimum=1
+	Value int64 `json:"value,omitempty"`
+
+	// +kubebuilder:validation:Enum=minutes;hours;days;weeks
+	// +kubebuilder:default:=hours
+	Unit string `json:"unit,omitempty"`
+}
+
+// MetricCap caps the metric value per user
+type MetricCap struct {
+	// Type caps the values either at an absolute value or at a percentile of all values
+	// +kubebuilder:validation:Enum=absolute;percentile
+	// +required
+	Type string `json:"type"`
+
+	// Value is the absolute cap or the percentile between 0 and 1
+	// +required
+	Value string `json:"value"`
+}
+
+// GetID returns the metric ID which is the resource name if not overwritten by spec.ID
+func (m *GrowthbookMetric) GetID() string {
+	if m.Spec.ID == "" {
+		return m.Name
+	}
+
+	return m.Spec.ID
+}
+
+// GetName returns the metric name which is the resource name if not overwritten by spec.Name
+func (m *GrowthbookMetric) GetName() string {
+	if m.Spec.Name == "" {
+		return m.Name
+	}
+
+	return m.Spec.Name
+}
+
+// GrowthbookMetricStatus defines the observed state of GrowthbookMetric
+type GrowthbookMetricStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookMetric) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookMetric) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookMetric is the Schema for the GrowthbookMetrics API
+type GrowthbookMetric struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookMetricSpec   `json:"spec,omitempty"`
+	Status GrowthbookMetricStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookMetricList contains a list of GrowthbookMetric
+type GrowthbookMetricList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookMetric `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookMetric{}, &GrowthbookMetricList{})
+}
@@ -31,6 +31,25 @@ type GrowthbookOrganizationSpec struct {
 
 	// ResourceSelector defines a selector to select Growthbook resources associated with this organization
 	ResourceSelector *metav1.LabelSelector `json:"resourceSelector,omitempty"`
+
+	// Environments declares the environments of the organization.
+	// If set, the environments are managed by the controller and features and clients may only reference declared environments.
+	Environments []GrowthbookOrganizationEnvironment `json:"environments,omitempty"`
+
+	// Namespaces declares the experiment namespaces of the organization.
+	// If set, the namespaces are managed by the controller and experiments may only use declared namespaces.
+	Namespaces []GrowthbookOrganizationNamespace `json:"namespaces,omitempty"`
+
+	// CustomRoles declares the custom roles of the organization in addition to the built-in roles.
+	// If set, the custom roles are managed by the controller.
+	CustomRoles []GrowthbookOrganizationCustomRole `json:"customRoles,omitempty"`
+
+	// RequireDeclaredTags only allows features to use tags which are declared by a GrowthbookTag of the organization
+	RequireDeclaredTags bool `json:"requireDeclaredTags,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // GrowthbookOrganizationUser defines which users are assigned to what organization with what role
@@ -39,6 +58,56 @@ type GrowthbookOrganizationUser struct {
 	Role     string                `json:"role,omitempty"`
 }
 
+// GrowthbookOrganizationEnvironment defines an environment of an organization
+type GrowthbookOrganizationEnvironment struct {
+	// Name is the environment id which is referenced by features and clients
+	// +required
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// ToggleOnList shows a toggle for this environment on the feature list
+	ToggleOnList bool `json:"toggleOnList,omitempty"`
+
+	// DefaultState defines whether new features are enabled in this environment
+	DefaultState bool `json:"defaultState,omitempty"`
+
+	// Projects scopes the environment to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+}
+
+// GrowthbookOrganizationNamespace defines an experiment namespace of an organization
+type GrowthbookOrganizationNamespace struct {
+	// Name is the namespace id which is referenced by experiments
+	// +required
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+
+	// Status defines whether experiments can be added to the namespace
+	// +kubebuilder:default:=active
+	// +kubebuilder:validation:Enum=active;inactive
+	Status NamespaceStatus `json:"status,omitempty"`
+}
+
+// NamespaceStatus defines the status of an experiment namespace
+type NamespaceStatus string
+
+var (
+	NamespaceStatusActive   NamespaceStatus = "active"
+	NamespaceStatusInactive NamespaceStatus = "inactive"
+)
+
+// GrowthbookOrganizationCustomRole defines a custom role of an organization
+type GrowthbookOrganizationCustomRole struct {
+	// ID is the role id which is referenced by users and teams
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_]+$`
+	// +required
+	ID          string `json:"id"`
+	Description string `json:"description,omitempty"`
+
+	// Permissions lists the growthbook permission policies granted by the role
+	Permissions []string `json:"permissions,omitempty"`
+}
+
 // GetID returns the organization ID which is the resource name if not overwritten by spec.ID
 func (o *GrowthbookOrganization) GetID() string {
 	if o.Spec.ID == "" {
@@ -57,7 +126,26 @@ func (o *GrowthbookOrganization) GetName() string {
 	return o.Spec.Name
 }
 
+// GrowthbookOrganizationStatus defines the observed state of GrowthbookOrganization
+type GrowthbookOrganizationStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookOrganization) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookOrganization) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 
 // GrowthbookOrganization is the Schema for the GrowthbookOrganizations API
@@ -65,7 +153,8 @@ type GrowthbookOrganization struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GrowthbookOrganizationSpec `json:"spec,omitempty"`
+	Spec   GrowthbookOrganizationSpec   `json:"spec,omitempty"`
+	Status GrowthbookOrganizationStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -0,0 +1,132 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookSavedGroupSpec defines the desired state of GrowthbookSavedGroup
+type GrowthbookSavedGroupSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Type is either condition which targets users by a condition or list which targets users by a list of attribute values
+	// +kubebuilder:default:=condition
+	// +kubebuilder:validation:Enum=condition;list
+	Type SavedGroupType `json:"type,omitempty"`
+
+	// Condition is the targeting condition of condition groups
+	Condition string `json:"condition,omitempty"`
+
+	// AttributeKey is the attribute matched against the values of list groups
+	AttributeKey string `json:"attributeKey,omitempty"`
+
+	// Values holds the attribute values of list groups
+	Values []string `json:"values,omitempty"`
+
+	// ValuesFrom adds the attribute values from a ConfigMap, one value per line
+	ValuesFrom *ConfigMapKeyReference `json:"valuesFrom,omitempty"`
+
+	// Projects scopes the saved group to the given GrowthbookProject resource names
+	Projects []string `json:"projects,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// SavedGroupType defines the type of a saved group
+type SavedGroupType string
+
+var (
+	SavedGroupTypeCondition SavedGroupType = "condition"
+	SavedGroupTypeList      SavedGroupType = "list"
+)
+
+// ConfigMapKeyReference is a reference to a key of a ConfigMap
+type ConfigMapKeyReference struct {
+	// Name referrs to the name of the ConfigMap, must be located whithin the same namespace
+	Name string `json:"name"`
+
+	// +kubebuilder:default:=values
+	Key string `json:"key,omitempty"`
+}
+
+// GetID returns the saved group ID which is the resource name if not overwritten by spec.ID
+func (s *GrowthbookSavedGroup) GetID() string {
+	if s.Spec.ID == "" {
+		return s.Name
+	}
+
+	return s.Spec.ID
+}
+
+// GetName returns the saved group name which is the resource name if not overwritten by spec.Name
+func (s *GrowthbookSavedGroup) GetName() string {
+	if s.Spec.Name == "" {
+		return s.Name
+	}
+
+	return s.Spec.Name
+}
+
+// GrowthbookSavedGroupStatus defines the observed state of GrowthbookSavedGroup
+type GrowthbookSavedGroupStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookSavedGroup) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookSavedGroup) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookSavedGroup is the Schema for the GrowthbookSavedGroups API
+type GrowthbookSavedGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookSavedGroupSpec   `json:"spec,omitempty"`
+	Status GrowthbookSavedGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookSavedGroupList contains a list of GrowthbookSavedGroup
+type GrowthbookSavedGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookSavedGroup `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookSavedGroup{}, &GrowthbookSavedGroupList{})
+}
@@ -0,0 +1,89 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookTagSpec defines the desired state of GrowthbookTag
+type GrowthbookTagSpec struct {
+	// Name is the tag referenced by features, defaults to the resource name
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Color is the hex color the tag is displayed with
+	// +kubebuilder:validation:Pattern=`^#[0-9a-fA-F]{6}$`
+	Color string `json:"color,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GetTag returns the tag which is the resource name if not overwritten by spec.Name
+func (t *GrowthbookTag) GetTag() string {
+	if t.Spec.Name == "" {
+		return t.Name
+	}
+
+	return t.Spec.Name
+}
+
+// GrowthbookTagStatus defines the observed state of GrowthbookTag
+type GrowthbookTagStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookTag) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookTag) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookTag is the Schema for the GrowthbookTags API
+type GrowthbookTag struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookTagSpec   `json:"spec,omitempty"`
+	Status GrowthbookTagStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookTagList contains a list of GrowthbookTag
+type GrowthbookTagList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookTag `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookTag{}, &GrowthbookTagList{})
+}
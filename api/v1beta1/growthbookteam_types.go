@@ -0,0 +1,123 @@
+/*
+
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GrowthbookTeamSpec defines the desired state of GrowthbookTeam
+type GrowthbookTeamSpec struct {
+	Name        string `json:"name,omitempty"`
+	ID          string `json:"id,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Role is the role the team grants its members within the organization
+	// +kubebuilder:default:=collaborator
+	Role string `json:"role,omitempty"`
+
+	// Environments limits the role to the given environments, the role applies to all environments if empty
+	Environments []string `json:"environments,omitempty"`
+
+	// ProjectRoles overrides the role of the team for specific projects
+	ProjectRoles []GrowthbookTeamProjectRole `json:"projectRoles,omitempty"`
+
+	// Users selects the GrowthbookUsers which are members of the team
+	Users *metav1.LabelSelector `json:"users,omitempty"`
+
+	// Emails adds the growthbook users with the given emails as members of the team
+	Emails []string `json:"emails,omitempty"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+}
+
+// GrowthbookTeamProjectRole defines the role of a team for a project
+type GrowthbookTeamProjectRole struct {
+	// Project references a GrowthbookProject by its resource name
+	// +required
+	Project string `json:"project"`
+
+	// +required
+	Role string `json:"role"`
+
+	// Environments limits the role to the given environments, the role applies to all environments if empty
+	Environments []string `json:"environments,omitempty"`
+}
+
+// GetID returns the team ID which is the resource name if not overwritten by spec.ID
+func (t *GrowthbookTeam) GetID() string {
+	if t.Spec.ID == "" {
+		return t.Name
+	}
+
+	return t.Spec.ID
+}
+
+// GetName returns the team name which is the resource name if not overwritten by spec.Name
+func (t *GrowthbookTeam) GetName() string {
+	if t.Spec.Name == "" {
+		return t.Name
+	}
+
+	return t.Spec.Name
+}
+
+// GrowthbookTeamStatus defines the observed state of GrowthbookTeam
+type GrowthbookTeamStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookTeam) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookTeam) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
+
+// GrowthbookTeam is the Schema for the GrowthbookTeams API
+type GrowthbookTeam struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GrowthbookTeamSpec   `json:"spec,omitempty"`
+	Status GrowthbookTeamStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GrowthbookTeamList contains a list of GrowthbookTeam
+type GrowthbookTeamList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GrowthbookTeam `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GrowthbookTeam{}, &GrowthbookTeamList{})
+}
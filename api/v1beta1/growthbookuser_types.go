@@ -28,6 +28,10 @@ type GrowthbookUserSpec struct {
 
 	// Secret is a secret reference to a secret containing the users password
 	Secret *SecretReference `json:"secret"`
+
+	// DeletionPolicy overrides the deletion policy of the instance
+	// +kubebuilder:validation:Enum=Delete;Orphan
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
 }
 
 // GetID returns the organization ID which is the resource name if not overwritten by spec.ID
@@ -48,7 +52,26 @@ func (u *GrowthbookUser) GetName() string {
 	return u.Spec.Name
 }
 
+// GrowthbookUserStatus defines the observed state of GrowthbookUser
+type GrowthbookUserStatus struct {
+	DocumentStatus `json:",inline"`
+}
+
+// GetDocumentStatus returns a pointer to the document status
+func (in *GrowthbookUser) GetDocumentStatus() *DocumentStatus {
+	return &in.Status.DocumentStatus
+}
+
+// GetStatusConditions returns a pointer to the Status.Conditions slice
+func (in *GrowthbookUser) GetStatusConditions() *[]metav1.Condition {
+	return &in.Status.Conditions
+}
+
 // +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].status",description=""
+// +kubebuilder:printcolumn:name="Status",type="string",JSONPath=".status.conditions[?(@.type==\"Ready\")].message",description=""
+// +kubebuilder:printcolumn:name="ID",type="string",JSONPath=".status.id",description=""
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description=""
 
 // GrowthbookUser is the Schema for the GrowthbookUsers API
@@ -56,7 +79,8 @@ type GrowthbookUser struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec GrowthbookUserSpec `json:"spec,omitempty"`
+	Spec   GrowthbookUserSpec   `json:"spec,omitempty"`
+	Status GrowthbookUserStatus `json:"status,omitempty"`
 }
 
 // +kubebuilder:object:root=true
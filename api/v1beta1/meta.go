@@ -26,9 +26,23 @@ const (
 	SynchronizedReason = "Synchronized"
 	ProgressingReason  = "Progressing"
 	FailedReason       = "Failed"
+	ConflictReason     = "Conflict"
+	DriftedReason      = "Drifted"
 	Finalizer          = "finalizers.doodle.com"
 )
 
+// DeletionPolicy defines what happens to a growthbook document if the related resource is deleted
+type DeletionPolicy string
+
+var (
+	// DeletionPolicyDelete removes the document from growthbook
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	// DeletionPolicyOrphan leaves the document untouched
+	DeletionPolicyOrphan DeletionPolicy = "Orphan"
+	// DeletionPolicyArchive archives the document, only supported by features, experiments and attributes
+	DeletionPolicyArchive DeletionPolicy = "Archive"
+)
+
 // ConditionalResource is a resource with conditions
 type conditionalResource interface {
 	GetStatusConditions() *[]metav1.Condition
@@ -62,3 +76,46 @@ type SecretReference struct {
 	// +kubebuilder:default:=password
 	PasswordField string `json:"passwordField,omitempty"`
 }
+
+// DocumentStatus describes the state of the growthbook document managed by a resource
+type DocumentStatus struct {
+	// Conditions holds the conditions of the resource
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// ObservedGeneration is the last generation applied by the controller
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// ID is the resolved growthbook document id
+	ID string `json:"id,omitempty"`
+
+	// Organization is the growthbook organization id the document belongs to
+	Organization string `json:"organization,omitempty"`
+
+	// Instance is the name of the GrowthbookInstance which manages the document
+	Instance string `json:"instance,omitempty"`
+
+	// LastAppliedTime is the last time the document has been written by the controller
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// DateUpdated is the dateUpdated field of the live growthbook document
+	DateUpdated *metav1.Time `json:"dateUpdated,omitempty"`
+
+	// Revision is the __v field of the live growthbook document
+	Revision int `json:"revision,omitempty"`
+}
+
+// GetStatusConditions returns a pointer to the Conditions slice
+func (in *DocumentStatus) GetStatusConditions() *[]metav1.Condition {
+	return &in.Conditions
+}
+
+// DocumentReady sets the ready condition to true
+func DocumentReady(status *DocumentStatus, reason, message string) {
+	setResourceCondition(status, ReadyCondition, metav1.ConditionTrue, reason, message)
+}
+
+// DocumentNotReady sets the ready condition to false
+func DocumentNotReady(status *DocumentStatus, reason, message string) {
+	setResourceCondition(status, ReadyCondition, metav1.ConditionFalse, reason, message)
+}
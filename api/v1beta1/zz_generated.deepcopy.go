@@ -25,6 +25,107 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapKeyReference) DeepCopyInto(out *ConfigMapKeyReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapKeyReference.
+func (in *ConfigMapKeyReference) DeepCopy() *ConfigMapKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DataSourceExposureQuery) DeepCopyInto(out *DataSourceExposureQuery) {
+	*out = *in
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DataSourceExposureQuery.
+func (in *DataSourceExposureQuery) DeepCopy() *DataSourceExposureQuery {
+	if in == nil {
+		return nil
+	}
+	out := new(DataSourceExposureQuery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DocumentStatus) DeepCopyInto(out *DocumentStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastAppliedTime != nil {
+		in, out := &in.LastAppliedTime, &out.LastAppliedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.DateUpdated != nil {
+		in, out := &in.DateUpdated, &out.DateUpdated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DocumentStatus.
+func (in *DocumentStatus) DeepCopy() *DocumentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DocumentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftedResource) DeepCopyInto(out *DriftedResource) {
+	*out = *in
+	out.ResourceReference = in.ResourceReference
+	if in.Fields != nil {
+		in, out := &in.Fields, &out.Fields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftedResource.
+func (in *DriftedResource) DeepCopy() *DriftedResource {
+	if in == nil {
+		return nil
+	}
+	out := new(DriftedResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EncryptionKeySecretReference) DeepCopyInto(out *EncryptionKeySecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EncryptionKeySecretReference.
+func (in *EncryptionKeySecretReference) DeepCopy() *EncryptionKeySecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(EncryptionKeySecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Environment) DeepCopyInto(out *Environment) {
 	*out = *in
@@ -47,6 +148,46 @@ func (in *Environment) DeepCopy() *Environment {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExperimentPhase) DeepCopyInto(out *ExperimentPhase) {
+	*out = *in
+	if in.DateStarted != nil {
+		in, out := &in.DateStarted, &out.DateStarted
+		*out = (*in).DeepCopy()
+	}
+	if in.DateEnded != nil {
+		in, out := &in.DateEnded, &out.DateEnded
+		*out = (*in).DeepCopy()
+	}
+	if in.VariationWeights != nil {
+		in, out := &in.VariationWeights, &out.VariationWeights
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SavedGroups != nil {
+		in, out := &in.SavedGroups, &out.SavedGroups
+		*out = make([]SavedGroupTargeting, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespace != nil {
+		in, out := &in.Namespace, &out.Namespace
+		*out = new(NamespaceValue)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExperimentPhase.
+func (in *ExperimentPhase) DeepCopy() *ExperimentPhase {
+	if in == nil {
+		return nil
+	}
+	out := new(ExperimentPhase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ExperimentRefVariation) DeepCopyInto(out *ExperimentRefVariation) {
 	*out = *in
@@ -82,6 +223,71 @@ func (in *ExperimentValue) DeepCopy() *ExperimentValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExperimentVariation) DeepCopyInto(out *ExperimentVariation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExperimentVariation.
+func (in *ExperimentVariation) DeepCopy() *ExperimentVariation {
+	if in == nil {
+		return nil
+	}
+	out := new(ExperimentVariation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FactColumnReference) DeepCopyInto(out *FactColumnReference) {
+	*out = *in
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FactColumnReference.
+func (in *FactColumnReference) DeepCopy() *FactColumnReference {
+	if in == nil {
+		return nil
+	}
+	out := new(FactColumnReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FactTableColumn) DeepCopyInto(out *FactTableColumn) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FactTableColumn.
+func (in *FactTableColumn) DeepCopy() *FactTableColumn {
+	if in == nil {
+		return nil
+	}
+	out := new(FactTableColumn)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FactTableFilter) DeepCopyInto(out *FactTableFilter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FactTableFilter.
+func (in *FactTableFilter) DeepCopy() *FactTableFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(FactTableFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FeaturePrerequisite) DeepCopyInto(out *FeaturePrerequisite) {
 	*out = *in
@@ -167,25 +373,26 @@ func (in *FeatureRule) DeepCopy() *FeatureRule {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookClient) DeepCopyInto(out *GrowthbookClient) {
+func (in *GrowthbookAPIKey) DeepCopyInto(out *GrowthbookAPIKey) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClient.
-func (in *GrowthbookClient) DeepCopy() *GrowthbookClient {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAPIKey.
+func (in *GrowthbookAPIKey) DeepCopy() *GrowthbookAPIKey {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookClient)
+	out := new(GrowthbookAPIKey)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookClient) DeepCopyObject() runtime.Object {
+func (in *GrowthbookAPIKey) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -193,31 +400,31 @@ func (in *GrowthbookClient) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookClientList) DeepCopyInto(out *GrowthbookClientList) {
+func (in *GrowthbookAPIKeyList) DeepCopyInto(out *GrowthbookAPIKeyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GrowthbookClient, len(*in))
+		*out = make([]GrowthbookAPIKey, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClientList.
-func (in *GrowthbookClientList) DeepCopy() *GrowthbookClientList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAPIKeyList.
+func (in *GrowthbookAPIKeyList) DeepCopy() *GrowthbookAPIKeyList {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookClientList)
+	out := new(GrowthbookAPIKeyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookClientList) DeepCopyObject() runtime.Object {
+func (in *GrowthbookAPIKeyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -225,13 +432,8 @@ func (in *GrowthbookClientList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookClientSpec) DeepCopyInto(out *GrowthbookClientSpec) {
+func (in *GrowthbookAPIKeySpec) DeepCopyInto(out *GrowthbookAPIKeySpec) {
 	*out = *in
-	if in.Languages != nil {
-		in, out := &in.Languages, &out.Languages
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
 	if in.TokenSecret != nil {
 		in, out := &in.TokenSecret, &out.TokenSecret
 		*out = new(TokenSecretReference)
@@ -239,36 +441,53 @@ func (in *GrowthbookClientSpec) DeepCopyInto(out *GrowthbookClientSpec) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClientSpec.
-func (in *GrowthbookClientSpec) DeepCopy() *GrowthbookClientSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAPIKeySpec.
+func (in *GrowthbookAPIKeySpec) DeepCopy() *GrowthbookAPIKeySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookClientSpec)
+	out := new(GrowthbookAPIKeySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookFeature) DeepCopyInto(out *GrowthbookFeature) {
+func (in *GrowthbookAPIKeyStatus) DeepCopyInto(out *GrowthbookAPIKeyStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAPIKeyStatus.
+func (in *GrowthbookAPIKeyStatus) DeepCopy() *GrowthbookAPIKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookAPIKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookArchetype) DeepCopyInto(out *GrowthbookArchetype) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeature.
-func (in *GrowthbookFeature) DeepCopy() *GrowthbookFeature {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookArchetype.
+func (in *GrowthbookArchetype) DeepCopy() *GrowthbookArchetype {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookFeature)
+	out := new(GrowthbookArchetype)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookFeature) DeepCopyObject() runtime.Object {
+func (in *GrowthbookArchetype) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -276,31 +495,31 @@ func (in *GrowthbookFeature) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookFeatureList) DeepCopyInto(out *GrowthbookFeatureList) {
+func (in *GrowthbookArchetypeList) DeepCopyInto(out *GrowthbookArchetypeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GrowthbookFeature, len(*in))
+		*out = make([]GrowthbookArchetype, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeatureList.
-func (in *GrowthbookFeatureList) DeepCopy() *GrowthbookFeatureList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookArchetypeList.
+func (in *GrowthbookArchetypeList) DeepCopy() *GrowthbookArchetypeList {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookFeatureList)
+	out := new(GrowthbookArchetypeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookFeatureList) DeepCopyObject() runtime.Object {
+func (in *GrowthbookArchetypeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -308,34 +527,38 @@ func (in *GrowthbookFeatureList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookFeatureSpec) DeepCopyInto(out *GrowthbookFeatureSpec) {
+func (in *GrowthbookArchetypeSpec) DeepCopyInto(out *GrowthbookArchetypeSpec) {
 	*out = *in
-	if in.Tags != nil {
-		in, out := &in.Tags, &out.Tags
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.Environments != nil {
-		in, out := &in.Environments, &out.Environments
-		*out = make([]Environment, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookArchetypeSpec.
+func (in *GrowthbookArchetypeSpec) DeepCopy() *GrowthbookArchetypeSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(GrowthbookArchetypeSpec)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeatureSpec.
-func (in *GrowthbookFeatureSpec) DeepCopy() *GrowthbookFeatureSpec {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookArchetypeStatus) DeepCopyInto(out *GrowthbookArchetypeStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookArchetypeStatus.
+func (in *GrowthbookArchetypeStatus) DeepCopy() *GrowthbookArchetypeStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookFeatureSpec)
+	out := new(GrowthbookArchetypeStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookInstance) DeepCopyInto(out *GrowthbookInstance) {
+func (in *GrowthbookAttribute) DeepCopyInto(out *GrowthbookAttribute) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -343,18 +566,18 @@ func (in *GrowthbookInstance) DeepCopyInto(out *GrowthbookInstance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstance.
-func (in *GrowthbookInstance) DeepCopy() *GrowthbookInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAttribute.
+func (in *GrowthbookAttribute) DeepCopy() *GrowthbookAttribute {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookInstance)
+	out := new(GrowthbookAttribute)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookInstance) DeepCopyObject() runtime.Object {
+func (in *GrowthbookAttribute) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -362,31 +585,31 @@ func (in *GrowthbookInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookInstanceList) DeepCopyInto(out *GrowthbookInstanceList) {
+func (in *GrowthbookAttributeList) DeepCopyInto(out *GrowthbookAttributeList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GrowthbookInstance, len(*in))
+		*out = make([]GrowthbookAttribute, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceList.
-func (in *GrowthbookInstanceList) DeepCopy() *GrowthbookInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAttributeList.
+func (in *GrowthbookAttributeList) DeepCopy() *GrowthbookAttributeList {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookInstanceList)
+	out := new(GrowthbookAttributeList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookInstanceList) DeepCopyObject() runtime.Object {
+func (in *GrowthbookAttributeList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -394,44 +617,896 @@ func (in *GrowthbookInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookInstanceMongoDB) DeepCopyInto(out *GrowthbookInstanceMongoDB) {
+func (in *GrowthbookAttributeSpec) DeepCopyInto(out *GrowthbookAttributeSpec) {
 	*out = *in
-	if in.Secret != nil {
-		in, out := &in.Secret, &out.Secret
-		*out = new(SecretReference)
-		**out = **in
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceMongoDB.
-func (in *GrowthbookInstanceMongoDB) DeepCopy() *GrowthbookInstanceMongoDB {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAttributeSpec.
+func (in *GrowthbookAttributeSpec) DeepCopy() *GrowthbookAttributeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookInstanceMongoDB)
+	out := new(GrowthbookAttributeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookInstanceSpec) DeepCopyInto(out *GrowthbookInstanceSpec) {
+func (in *GrowthbookAttributeStatus) DeepCopyInto(out *GrowthbookAttributeStatus) {
 	*out = *in
-	in.MongoDB.DeepCopyInto(&out.MongoDB)
-	if in.Interval != nil {
-		in, out := &in.Interval, &out.Interval
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.Timeout != nil {
-		in, out := &in.Timeout, &out.Timeout
-		*out = new(v1.Duration)
-		**out = **in
-	}
-	if in.ResourceSelector != nil {
-		in, out := &in.ResourceSelector, &out.ResourceSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
-	}
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookAttributeStatus.
+func (in *GrowthbookAttributeStatus) DeepCopy() *GrowthbookAttributeStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookAttributeStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookClient) DeepCopyInto(out *GrowthbookClient) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClient.
+func (in *GrowthbookClient) DeepCopy() *GrowthbookClient {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookClient)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookClient) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookClientList) DeepCopyInto(out *GrowthbookClientList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookClient, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClientList.
+func (in *GrowthbookClientList) DeepCopy() *GrowthbookClientList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookClientList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookClientList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookClientSpec) DeepCopyInto(out *GrowthbookClientSpec) {
+	*out = *in
+	if in.Languages != nil {
+		in, out := &in.Languages, &out.Languages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TokenSecret != nil {
+		in, out := &in.TokenSecret, &out.TokenSecret
+		*out = new(TokenSecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClientSpec.
+func (in *GrowthbookClientSpec) DeepCopy() *GrowthbookClientSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookClientSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookClientStatus) DeepCopyInto(out *GrowthbookClientStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookClientStatus.
+func (in *GrowthbookClientStatus) DeepCopy() *GrowthbookClientStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookClientStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDataSource) DeepCopyInto(out *GrowthbookDataSource) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDataSource.
+func (in *GrowthbookDataSource) DeepCopy() *GrowthbookDataSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDataSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookDataSource) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDataSourceList) DeepCopyInto(out *GrowthbookDataSourceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookDataSource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDataSourceList.
+func (in *GrowthbookDataSourceList) DeepCopy() *GrowthbookDataSourceList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDataSourceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookDataSourceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDataSourceSpec) DeepCopyInto(out *GrowthbookDataSourceSpec) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ParamsSecret != nil {
+		in, out := &in.ParamsSecret, &out.ParamsSecret
+		*out = new(ParamsSecretReference)
+		**out = **in
+	}
+	if in.Queries != nil {
+		in, out := &in.Queries, &out.Queries
+		*out = make([]DataSourceExposureQuery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDataSourceSpec.
+func (in *GrowthbookDataSourceSpec) DeepCopy() *GrowthbookDataSourceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDataSourceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDataSourceStatus) DeepCopyInto(out *GrowthbookDataSourceStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDataSourceStatus.
+func (in *GrowthbookDataSourceStatus) DeepCopy() *GrowthbookDataSourceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDataSourceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDimension) DeepCopyInto(out *GrowthbookDimension) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDimension.
+func (in *GrowthbookDimension) DeepCopy() *GrowthbookDimension {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDimension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookDimension) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDimensionList) DeepCopyInto(out *GrowthbookDimensionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookDimension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDimensionList.
+func (in *GrowthbookDimensionList) DeepCopy() *GrowthbookDimensionList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDimensionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookDimensionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDimensionSpec) DeepCopyInto(out *GrowthbookDimensionSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDimensionSpec.
+func (in *GrowthbookDimensionSpec) DeepCopy() *GrowthbookDimensionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDimensionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookDimensionStatus) DeepCopyInto(out *GrowthbookDimensionStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookDimensionStatus.
+func (in *GrowthbookDimensionStatus) DeepCopy() *GrowthbookDimensionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookDimensionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookExperiment) DeepCopyInto(out *GrowthbookExperiment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookExperiment.
+func (in *GrowthbookExperiment) DeepCopy() *GrowthbookExperiment {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookExperiment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookExperiment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookExperimentList) DeepCopyInto(out *GrowthbookExperimentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookExperiment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookExperimentList.
+func (in *GrowthbookExperimentList) DeepCopy() *GrowthbookExperimentList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookExperimentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookExperimentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookExperimentSpec) DeepCopyInto(out *GrowthbookExperimentSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Variations != nil {
+		in, out := &in.Variations, &out.Variations
+		*out = make([]ExperimentVariation, len(*in))
+		copy(*out, *in)
+	}
+	if in.Phases != nil {
+		in, out := &in.Phases, &out.Phases
+		*out = make([]ExperimentPhase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookExperimentSpec.
+func (in *GrowthbookExperimentSpec) DeepCopy() *GrowthbookExperimentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookExperimentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookExperimentStatus) DeepCopyInto(out *GrowthbookExperimentStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookExperimentStatus.
+func (in *GrowthbookExperimentStatus) DeepCopy() *GrowthbookExperimentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookExperimentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactMetric) DeepCopyInto(out *GrowthbookFactMetric) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactMetric.
+func (in *GrowthbookFactMetric) DeepCopy() *GrowthbookFactMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookFactMetric) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactMetricList) DeepCopyInto(out *GrowthbookFactMetricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookFactMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactMetricList.
+func (in *GrowthbookFactMetricList) DeepCopy() *GrowthbookFactMetricList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactMetricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookFactMetricList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactMetricSpec) DeepCopyInto(out *GrowthbookFactMetricSpec) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Numerator.DeepCopyInto(&out.Numerator)
+	if in.Denominator != nil {
+		in, out := &in.Denominator, &out.Denominator
+		*out = new(FactColumnReference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(MetricWindow)
+		**out = **in
+	}
+	if in.Cap != nil {
+		in, out := &in.Cap, &out.Cap
+		*out = new(MetricCap)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactMetricSpec.
+func (in *GrowthbookFactMetricSpec) DeepCopy() *GrowthbookFactMetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactMetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactMetricStatus) DeepCopyInto(out *GrowthbookFactMetricStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactMetricStatus.
+func (in *GrowthbookFactMetricStatus) DeepCopy() *GrowthbookFactMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactTable) DeepCopyInto(out *GrowthbookFactTable) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactTable.
+func (in *GrowthbookFactTable) DeepCopy() *GrowthbookFactTable {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactTable)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookFactTable) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactTableList) DeepCopyInto(out *GrowthbookFactTableList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookFactTable, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactTableList.
+func (in *GrowthbookFactTableList) DeepCopy() *GrowthbookFactTableList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactTableList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookFactTableList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactTableSpec) DeepCopyInto(out *GrowthbookFactTableSpec) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserIDTypes != nil {
+		in, out := &in.UserIDTypes, &out.UserIDTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Columns != nil {
+		in, out := &in.Columns, &out.Columns
+		*out = make([]FactTableColumn, len(*in))
+		copy(*out, *in)
+	}
+	if in.Filters != nil {
+		in, out := &in.Filters, &out.Filters
+		*out = make([]FactTableFilter, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactTableSpec.
+func (in *GrowthbookFactTableSpec) DeepCopy() *GrowthbookFactTableSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactTableSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFactTableStatus) DeepCopyInto(out *GrowthbookFactTableStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFactTableStatus.
+func (in *GrowthbookFactTableStatus) DeepCopy() *GrowthbookFactTableStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFactTableStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFeature) DeepCopyInto(out *GrowthbookFeature) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeature.
+func (in *GrowthbookFeature) DeepCopy() *GrowthbookFeature {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFeature)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookFeature) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFeatureList) DeepCopyInto(out *GrowthbookFeatureList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookFeature, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeatureList.
+func (in *GrowthbookFeatureList) DeepCopy() *GrowthbookFeatureList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFeatureList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookFeatureList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFeatureSpec) DeepCopyInto(out *GrowthbookFeatureSpec) {
+	*out = *in
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]Environment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeatureSpec.
+func (in *GrowthbookFeatureSpec) DeepCopy() *GrowthbookFeatureSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFeatureSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookFeatureStatus) DeepCopyInto(out *GrowthbookFeatureStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+	if in.UnknownAttributes != nil {
+		in, out := &in.UnknownAttributes, &out.UnknownAttributes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ManagedRules != nil {
+		in, out := &in.ManagedRules, &out.ManagedRules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookFeatureStatus.
+func (in *GrowthbookFeatureStatus) DeepCopy() *GrowthbookFeatureStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookFeatureStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookInstance) DeepCopyInto(out *GrowthbookInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstance.
+func (in *GrowthbookInstance) DeepCopy() *GrowthbookInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookInstanceList) DeepCopyInto(out *GrowthbookInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceList.
+func (in *GrowthbookInstanceList) DeepCopy() *GrowthbookInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookInstanceMongoDB) DeepCopyInto(out *GrowthbookInstanceMongoDB) {
+	*out = *in
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceMongoDB.
+func (in *GrowthbookInstanceMongoDB) DeepCopy() *GrowthbookInstanceMongoDB {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookInstanceMongoDB)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookInstanceSpec) DeepCopyInto(out *GrowthbookInstanceSpec) {
+	*out = *in
+	in.MongoDB.DeepCopyInto(&out.MongoDB)
+	if in.EncryptionKeySecret != nil {
+		in, out := &in.EncryptionKeySecret, &out.EncryptionKeySecret
+		*out = new(EncryptionKeySecretReference)
+		**out = **in
+	}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceSpec.
@@ -439,59 +1514,677 @@ func (in *GrowthbookInstanceSpec) DeepCopy() *GrowthbookInstanceSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookInstanceSpec)
+	out := new(GrowthbookInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookInstanceStatus) DeepCopyInto(out *GrowthbookInstanceStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	out.LastReconcileDuration = in.LastReconcileDuration
+	if in.SubResourceCatalog != nil {
+		in, out := &in.SubResourceCatalog, &out.SubResourceCatalog
+		*out = make([]ResourceReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.Inventory != nil {
+		in, out := &in.Inventory, &out.Inventory
+		*out = make([]InventoryEntry, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedResources != nil {
+		in, out := &in.DriftedResources, &out.DriftedResources
+		*out = make([]DriftedResource, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceStatus.
+func (in *GrowthbookInstanceStatus) DeepCopy() *GrowthbookInstanceStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookInstanceStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookMetric) DeepCopyInto(out *GrowthbookMetric) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookMetric.
+func (in *GrowthbookMetric) DeepCopy() *GrowthbookMetric {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookMetric)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookMetric) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookMetricList) DeepCopyInto(out *GrowthbookMetricList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookMetric, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookMetricList.
+func (in *GrowthbookMetricList) DeepCopy() *GrowthbookMetricList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookMetricList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookMetricList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookMetricSpec) DeepCopyInto(out *GrowthbookMetricSpec) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.UserIDTypes != nil {
+		in, out := &in.UserIDTypes, &out.UserIDTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]MetricCondition, len(*in))
+		copy(*out, *in)
+	}
+	if in.Window != nil {
+		in, out := &in.Window, &out.Window
+		*out = new(MetricWindow)
+		**out = **in
+	}
+	if in.Cap != nil {
+		in, out := &in.Cap, &out.Cap
+		*out = new(MetricCap)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookMetricSpec.
+func (in *GrowthbookMetricSpec) DeepCopy() *GrowthbookMetricSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookMetricSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookMetricStatus) DeepCopyInto(out *GrowthbookMetricStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookMetricStatus.
+func (in *GrowthbookMetricStatus) DeepCopy() *GrowthbookMetricStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookMetricStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganization) DeepCopyInto(out *GrowthbookOrganization) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganization.
+func (in *GrowthbookOrganization) DeepCopy() *GrowthbookOrganization {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganization)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookOrganization) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationCustomRole) DeepCopyInto(out *GrowthbookOrganizationCustomRole) {
+	*out = *in
+	if in.Permissions != nil {
+		in, out := &in.Permissions, &out.Permissions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationCustomRole.
+func (in *GrowthbookOrganizationCustomRole) DeepCopy() *GrowthbookOrganizationCustomRole {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationCustomRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationEnvironment) DeepCopyInto(out *GrowthbookOrganizationEnvironment) {
+	*out = *in
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationEnvironment.
+func (in *GrowthbookOrganizationEnvironment) DeepCopy() *GrowthbookOrganizationEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationList) DeepCopyInto(out *GrowthbookOrganizationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookOrganization, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationList.
+func (in *GrowthbookOrganizationList) DeepCopy() *GrowthbookOrganizationList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookOrganizationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationNamespace) DeepCopyInto(out *GrowthbookOrganizationNamespace) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationNamespace.
+func (in *GrowthbookOrganizationNamespace) DeepCopy() *GrowthbookOrganizationNamespace {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationNamespace)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationSpec) DeepCopyInto(out *GrowthbookOrganizationSpec) {
+	*out = *in
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
+		*out = make([]*GrowthbookOrganizationUser, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(GrowthbookOrganizationUser)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.ResourceSelector != nil {
+		in, out := &in.ResourceSelector, &out.ResourceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]GrowthbookOrganizationEnvironment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]GrowthbookOrganizationNamespace, len(*in))
+		copy(*out, *in)
+	}
+	if in.CustomRoles != nil {
+		in, out := &in.CustomRoles, &out.CustomRoles
+		*out = make([]GrowthbookOrganizationCustomRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationSpec.
+func (in *GrowthbookOrganizationSpec) DeepCopy() *GrowthbookOrganizationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationStatus) DeepCopyInto(out *GrowthbookOrganizationStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationStatus.
+func (in *GrowthbookOrganizationStatus) DeepCopy() *GrowthbookOrganizationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookOrganizationUser) DeepCopyInto(out *GrowthbookOrganizationUser) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationUser.
+func (in *GrowthbookOrganizationUser) DeepCopy() *GrowthbookOrganizationUser {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookOrganizationUser)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookProject) DeepCopyInto(out *GrowthbookProject) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookProject.
+func (in *GrowthbookProject) DeepCopy() *GrowthbookProject {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookProject)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookProject) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookProjectList) DeepCopyInto(out *GrowthbookProjectList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookProject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookProjectList.
+func (in *GrowthbookProjectList) DeepCopy() *GrowthbookProjectList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookProjectList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookProjectList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookProjectSpec) DeepCopyInto(out *GrowthbookProjectSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookProjectSpec.
+func (in *GrowthbookProjectSpec) DeepCopy() *GrowthbookProjectSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookProjectSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookProjectStatus) DeepCopyInto(out *GrowthbookProjectStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookProjectStatus.
+func (in *GrowthbookProjectStatus) DeepCopy() *GrowthbookProjectStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookProjectStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookSavedGroup) DeepCopyInto(out *GrowthbookSavedGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSavedGroup.
+func (in *GrowthbookSavedGroup) DeepCopy() *GrowthbookSavedGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSavedGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookSavedGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookSavedGroupList) DeepCopyInto(out *GrowthbookSavedGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookSavedGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSavedGroupList.
+func (in *GrowthbookSavedGroupList) DeepCopy() *GrowthbookSavedGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSavedGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookSavedGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookSavedGroupSpec) DeepCopyInto(out *GrowthbookSavedGroupSpec) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ValuesFrom != nil {
+		in, out := &in.ValuesFrom, &out.ValuesFrom
+		*out = new(ConfigMapKeyReference)
+		**out = **in
+	}
+	if in.Projects != nil {
+		in, out := &in.Projects, &out.Projects
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSavedGroupSpec.
+func (in *GrowthbookSavedGroupSpec) DeepCopy() *GrowthbookSavedGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSavedGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookSavedGroupStatus) DeepCopyInto(out *GrowthbookSavedGroupStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSavedGroupStatus.
+func (in *GrowthbookSavedGroupStatus) DeepCopy() *GrowthbookSavedGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSavedGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookSegment) DeepCopyInto(out *GrowthbookSegment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSegment.
+func (in *GrowthbookSegment) DeepCopy() *GrowthbookSegment {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSegment)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookSegment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookInstanceStatus) DeepCopyInto(out *GrowthbookInstanceStatus) {
+func (in *GrowthbookSegmentList) DeepCopyInto(out *GrowthbookSegmentList) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookSegment, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	out.LastReconcileDuration = in.LastReconcileDuration
-	if in.SubResourceCatalog != nil {
-		in, out := &in.SubResourceCatalog, &out.SubResourceCatalog
-		*out = make([]ResourceReference, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSegmentList.
+func (in *GrowthbookSegmentList) DeepCopy() *GrowthbookSegmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSegmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookSegmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
+	return nil
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookInstanceStatus.
-func (in *GrowthbookInstanceStatus) DeepCopy() *GrowthbookInstanceStatus {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookSegmentSpec) DeepCopyInto(out *GrowthbookSegmentSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSegmentSpec.
+func (in *GrowthbookSegmentSpec) DeepCopy() *GrowthbookSegmentSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookInstanceStatus)
+	out := new(GrowthbookSegmentSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookOrganization) DeepCopyInto(out *GrowthbookOrganization) {
+func (in *GrowthbookSegmentStatus) DeepCopyInto(out *GrowthbookSegmentStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookSegmentStatus.
+func (in *GrowthbookSegmentStatus) DeepCopy() *GrowthbookSegmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookSegmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookTag) DeepCopyInto(out *GrowthbookTag) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganization.
-func (in *GrowthbookOrganization) DeepCopy() *GrowthbookOrganization {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTag.
+func (in *GrowthbookTag) DeepCopy() *GrowthbookTag {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookOrganization)
+	out := new(GrowthbookTag)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookOrganization) DeepCopyObject() runtime.Object {
+func (in *GrowthbookTag) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -499,31 +2192,31 @@ func (in *GrowthbookOrganization) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookOrganizationList) DeepCopyInto(out *GrowthbookOrganizationList) {
+func (in *GrowthbookTagList) DeepCopyInto(out *GrowthbookTagList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]GrowthbookOrganization, len(*in))
+		*out = make([]GrowthbookTag, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationList.
-func (in *GrowthbookOrganizationList) DeepCopy() *GrowthbookOrganizationList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTagList.
+func (in *GrowthbookTagList) DeepCopy() *GrowthbookTagList {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookOrganizationList)
+	out := new(GrowthbookTagList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *GrowthbookOrganizationList) DeepCopyObject() runtime.Object {
+func (in *GrowthbookTagList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -531,52 +2224,164 @@ func (in *GrowthbookOrganizationList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookOrganizationSpec) DeepCopyInto(out *GrowthbookOrganizationSpec) {
+func (in *GrowthbookTagSpec) DeepCopyInto(out *GrowthbookTagSpec) {
 	*out = *in
-	if in.Users != nil {
-		in, out := &in.Users, &out.Users
-		*out = make([]*GrowthbookOrganizationUser, len(*in))
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTagSpec.
+func (in *GrowthbookTagSpec) DeepCopy() *GrowthbookTagSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookTagSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookTagStatus) DeepCopyInto(out *GrowthbookTagStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTagStatus.
+func (in *GrowthbookTagStatus) DeepCopy() *GrowthbookTagStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookTagStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookTeam) DeepCopyInto(out *GrowthbookTeam) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTeam.
+func (in *GrowthbookTeam) DeepCopy() *GrowthbookTeam {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookTeam)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookTeam) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookTeamList) DeepCopyInto(out *GrowthbookTeamList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GrowthbookTeam, len(*in))
 		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(GrowthbookOrganizationUser)
-				(*in).DeepCopyInto(*out)
-			}
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.ResourceSelector != nil {
-		in, out := &in.ResourceSelector, &out.ResourceSelector
-		*out = new(v1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTeamList.
+func (in *GrowthbookTeamList) DeepCopy() *GrowthbookTeamList {
+	if in == nil {
+		return nil
 	}
+	out := new(GrowthbookTeamList)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationSpec.
-func (in *GrowthbookOrganizationSpec) DeepCopy() *GrowthbookOrganizationSpec {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GrowthbookTeamList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookTeamProjectRole) DeepCopyInto(out *GrowthbookTeamProjectRole) {
+	*out = *in
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTeamProjectRole.
+func (in *GrowthbookTeamProjectRole) DeepCopy() *GrowthbookTeamProjectRole {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookOrganizationSpec)
+	out := new(GrowthbookTeamProjectRole)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *GrowthbookOrganizationUser) DeepCopyInto(out *GrowthbookOrganizationUser) {
+func (in *GrowthbookTeamSpec) DeepCopyInto(out *GrowthbookTeamSpec) {
 	*out = *in
-	if in.Selector != nil {
-		in, out := &in.Selector, &out.Selector
+	if in.Environments != nil {
+		in, out := &in.Environments, &out.Environments
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProjectRoles != nil {
+		in, out := &in.ProjectRoles, &out.ProjectRoles
+		*out = make([]GrowthbookTeamProjectRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Users != nil {
+		in, out := &in.Users, &out.Users
 		*out = new(v1.LabelSelector)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Emails != nil {
+		in, out := &in.Emails, &out.Emails
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookOrganizationUser.
-func (in *GrowthbookOrganizationUser) DeepCopy() *GrowthbookOrganizationUser {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTeamSpec.
+func (in *GrowthbookTeamSpec) DeepCopy() *GrowthbookTeamSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(GrowthbookOrganizationUser)
+	out := new(GrowthbookTeamSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookTeamStatus) DeepCopyInto(out *GrowthbookTeamStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookTeamStatus.
+func (in *GrowthbookTeamStatus) DeepCopy() *GrowthbookTeamStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookTeamStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -587,6 +2392,7 @@ func (in *GrowthbookUser) DeepCopyInto(out *GrowthbookUser) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookUser.
@@ -659,6 +2465,83 @@ func (in *GrowthbookUserSpec) DeepCopy() *GrowthbookUserSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrowthbookUserStatus) DeepCopyInto(out *GrowthbookUserStatus) {
+	*out = *in
+	in.DocumentStatus.DeepCopyInto(&out.DocumentStatus)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrowthbookUserStatus.
+func (in *GrowthbookUserStatus) DeepCopy() *GrowthbookUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GrowthbookUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InventoryEntry) DeepCopyInto(out *InventoryEntry) {
+	*out = *in
+	out.ResourceReference = in.ResourceReference
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InventoryEntry.
+func (in *InventoryEntry) DeepCopy() *InventoryEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(InventoryEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricCap) DeepCopyInto(out *MetricCap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricCap.
+func (in *MetricCap) DeepCopy() *MetricCap {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricCap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricCondition) DeepCopyInto(out *MetricCondition) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricCondition.
+func (in *MetricCondition) DeepCopy() *MetricCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MetricWindow) DeepCopyInto(out *MetricWindow) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MetricWindow.
+func (in *MetricWindow) DeepCopy() *MetricWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(MetricWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespaceValue) DeepCopyInto(out *NamespaceValue) {
 	*out = *in
@@ -679,6 +2562,21 @@ func (in *NamespaceValue) DeepCopy() *NamespaceValue {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParamsSecretReference) DeepCopyInto(out *ParamsSecretReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParamsSecretReference.
+func (in *ParamsSecretReference) DeepCopy() *ParamsSecretReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ParamsSecretReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
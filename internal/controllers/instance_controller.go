@@ -18,19 +18,28 @@ package controllers
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"golang.org/x/exp/slices"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -52,19 +61,436 @@ import (
 // +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookinstances,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookinstances/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookorganizations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookorganizations/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookusers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookusers/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookfeatures,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookfeatures/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookclients,verbs=get;list;watch;create;update;patch;delete
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookclients/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookprojects,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookprojects/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbooksavedgroups,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbooksavedgroups/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookexperiments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookexperiments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookattributes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookattributes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbooktags,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbooktags/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookapikeys,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookapikeys/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookteams,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookteams/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookarchetypes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookarchetypes/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookdatasources,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookdatasources/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookmetrics,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookmetrics/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookfacttables,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookfacttables/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookfactmetrics,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookfactmetrics/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbooksegments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbooksegments/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookdimensions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=growthbook.infra.doodle.com,resources=growthbookdimensions/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 const (
-	secretIndexKey = ".metadata.secret"
-	usersIndexKey  = ".metadata.users"
-	orgsIndexKey   = ".metadata.orgs"
-	owner          = "growthbook-controller"
+	secretIndexKey    = ".metadata.secret"
+	configMapIndexKey = ".metadata.configmap"
+	inventoryIndexKey = ".status.inventory"
+	usersIndexKey     = ".metadata.users"
+	orgsIndexKey      = ".metadata.orgs"
+	owner             = "growthbook-controller"
 )
 
+// errConflict is returned if the same growthbook id is claimed by multiple resources within an organization
+var errConflict = errors.New("conflict")
+
+// reconcileState holds data shared between all stages of a single GrowthbookInstance reconciliation.
+// The documents of an organization are written using a separate state for each transaction attempt,
+// it is only merged once the transaction has been committed.
+type reconcileState struct {
+	// previousInventory is the inventory of the previous reconciliation
+	previousInventory []v1beta1.InventoryEntry
+	// changes collects the changes written to growthbook
+	changes growthbook.ChangeSet
+	// stale collects the payloads of aborted transaction attempts, they are invalidated but not notified
+	stale growthbook.ChangeSet
+	// documents holds the outcome of each applied document by the resource it was rendered from
+	documents map[v1beta1.ResourceReference]documentResult
+	// releases holds the resources whose finalizer is removed once the transaction has been committed
+	releases []metav1.PartialObjectMetadata
+	// events holds the events recorded once the transaction has been committed
+	events []event
+	// tokens holds the api keys which are written to their secret once the transaction has been committed
+	tokens []apiKeyToken
+	// rotations holds the keys generated for api keys which are due for rotation by resource
+	rotations map[types.NamespacedName]apiKeyRotation
+}
+
+// apiKeyRotation is a key generated for the value of the rotate annotation of an api key
+type apiKeyRotation struct {
+	rotation string
+	key      string
+}
+
+// apiKeyToken is an applied api key whose stored key is written to its token secret
+type apiKeyToken struct {
+	apiKey v1beta1.GrowthbookAPIKey
+	doc    document
+}
+
+// event is a kubernetes event which is recorded for an object
+type event struct {
+	object    runtime.Object
+	eventtype string
+	reason    string
+	message   string
+}
+
+// record stores the outcome of applying a document
+func (s *reconcileState) record(result documentResult) {
+	if s.documents == nil {
+		s.documents = make(map[v1beta1.ResourceReference]documentResult)
+	}
+
+	s.documents[newResourceReference(result.resource)] = result
+}
+
+// release removes the finalizer from a resource once the transaction has been committed
+func (s *reconcileState) release(obj metav1.PartialObjectMetadata) {
+	s.releases = append(s.releases, obj)
+}
+
+// eventf records an event once the transaction has been committed
+func (s *reconcileState) eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	s.events = append(s.events, event{
+		object:    object,
+		eventtype: eventtype,
+		reason:    reason,
+		message:   fmt.Sprintf(messageFmt, args...),
+	})
+}
+
+// commit takes over the outcomes and changes of a committed transaction attempt
+func (s *reconcileState) commit(attempt *reconcileState) {
+	for _, result := range attempt.documents {
+		s.record(result)
+	}
+
+	s.changes.Merge(attempt.changes)
+}
+
+// abort takes over the failed outcomes of an aborted transaction attempt, nothing else has been committed.
+// Payloads are still invalidated as the documents have been written if the database does not support transactions,
+// webhooks are not notified about changes which might have been rolled back.
+func (s *reconcileState) abort(attempt *reconcileState) {
+	if attempt == nil {
+		return
+	}
+
+	for _, result := range attempt.documents {
+		if result.err != nil {
+			s.record(result)
+		}
+	}
+
+	for org, envs := range attempt.changes.Environments() {
+		s.stale.Invalidate(org, envs...)
+	}
+}
+
+// organizationReferences maps resource names to the ids of the growthbook documents within an organization
+type organizationReferences struct {
+	projects    map[string]string
+	savedGroups map[string]string
+	experiments map[string]string
+	dataSources map[string]string
+	factTables  map[string]factTableReference
+	// variations maps variation ids, keys and names to variation ids by experiment resource name
+	variations map[string]map[string]string
+	// environments holds the environments declared by the organization, nil if they are not managed
+	environments []string
+	// attributes holds the attributes of the organization attribute schema, nil if the schema is not known
+	attributes []string
+	// tags holds the tags declared by GrowthbookTags, nil if the organization does not require declared tags
+	tags []string
+	// roles holds the built-in and custom roles of the organization
+	roles []string
+	// namespaces holds the experiment namespaces declared by the organization, nil if they are not managed
+	namespaces []string
+	// namespaceAllocations holds the namespace ranges used by running experiments
+	namespaceAllocations []namespaceAllocation
+}
+
+// factTableReference holds the parts of a GrowthbookFactTable which are referenced by fact metrics
+type factTableReference struct {
+	id         string
+	dataSource string
+	// columns holds the declared columns, nil if the columns are detected by growthbook
+	columns []string
+	filters []string
+}
+
+func newFactTableReference(factTable v1beta1.GrowthbookFactTable) factTableReference {
+	ref := factTableReference{
+		id:         factTable.GetID(),
+		dataSource: factTable.Spec.DataSource,
+	}
+
+	if factTable.Spec.Columns != nil {
+		ref.columns = []string{}
+	}
+
+	for _, column := range factTable.Spec.Columns {
+		ref.columns = append(ref.columns, column.Column)
+	}
+
+	for _, filter := range factTable.Spec.Filters {
+		ref.filters = append(ref.filters, filter.ID)
+	}
+
+	return ref
+}
+
+// namespaceAllocation is a namespace range used by a running experiment
+type namespaceAllocation struct {
+	namespace  string
+	start, end float64
+	// environment is the environment of a feature rule, empty if the experiment is not bound to an environment
+	environment string
+	resource    string
+}
+
+// project returns the id of the referenced GrowthbookProject, an empty name resolves to no project
+func (r *organizationReferences) project(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	id, ok := r.projects[name]
+	if !ok {
+		return "", fmt.Errorf("referenced project %s not found", name)
+	}
+
+	return id, nil
+}
+
+// dataSource returns the id of the referenced GrowthbookDataSource
+func (r *organizationReferences) dataSource(name string) (string, error) {
+	id, ok := r.dataSources[name]
+	if !ok {
+		return "", fmt.Errorf("referenced data source %s not found", name)
+	}
+
+	return id, nil
+}
+
+// factColumn validates a reference to a column of a GrowthbookFactTable and returns the id of the fact table.
+// The fact table must belong to the given data source, columns are only validated if the fact table declares them.
+func (r *organizationReferences) factColumn(dataSource string, ref v1beta1.FactColumnReference) (string, error) {
+	factTable, ok := r.factTables[ref.FactTable]
+	if !ok {
+		return "", fmt.Errorf("referenced fact table %s not found", ref.FactTable)
+	}
+
+	if factTable.dataSource != dataSource {
+		return "", fmt.Errorf("referenced fact table %s belongs to data source %s instead of %s", ref.FactTable, factTable.dataSource, dataSource)
+	}
+
+	switch {
+	case ref.Column == "" || ref.Column == "$$count" || ref.Column == "$$distinctUsers":
+	case factTable.columns != nil && !slices.Contains(factTable.columns, ref.Column):
+		return "", fmt.Errorf("column %s is not declared by fact table %s", ref.Column, ref.FactTable)
+	}
+
+	for _, filter := range ref.Filters {
+		if !slices.Contains(factTable.filters, filter) {
+			return "", fmt.Errorf("filter %s is not declared by fact table %s", filter, ref.FactTable)
+		}
+	}
+
+	return factTable.id, nil
+}
+
+// savedGroup returns the id of the referenced GrowthbookSavedGroup.
+// Names which do not match any GrowthbookSavedGroup must be the id of an existing saved group in the organization.
+func (r *organizationReferences) savedGroup(ctx context.Context, organization, name string, db storage.Database) (string, error) {
+	if id, ok := r.savedGroups[name]; ok {
+		return id, nil
+	}
+
+	exists, err := growthbook.SavedGroupExists(ctx, growthbook.SavedGroup{ID: name, Organization: organization}, db)
+	if err != nil {
+		return "", fmt.Errorf("failed looking up saved group %s: %w", name, err)
+	}
+
+	if !exists {
+		return "", fmt.Errorf("referenced saved group %s not found", name)
+	}
+
+	return name, nil
+}
+
+// experiment returns the id of the referenced GrowthbookExperiment and its variation ids by id, key and name.
+// Names which do not match any GrowthbookExperiment must be the id of an existing experiment in the organization.
+func (r *organizationReferences) experiment(ctx context.Context, organization, name string, db storage.Database) (string, map[string]string, error) {
+	if id, ok := r.experiments[name]; ok {
+		return id, r.variations[name], nil
+	}
+
+	experiment, exists, err := growthbook.FindExperiment(ctx, growthbook.Experiment{ID: name, Organization: organization}, db)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed looking up experiment %s: %w", name, err)
+	}
+
+	if !exists {
+		return "", nil, fmt.Errorf("referenced experiment %s not found", name)
+	}
+
+	return experiment.ID, variationIDs(experiment), nil
+}
+
+// variationIDs maps the ids, keys and names of the variations of an experiment to their ids
+func variationIDs(experiment growthbook.Experiment) map[string]string {
+	ids := make(map[string]string)
+	for _, variation := range experiment.Variations {
+		ids[variation.ID] = variation.ID
+		ids[variation.Key] = variation.ID
+		if variation.Name != "" {
+			ids[variation.Name] = variation.ID
+		}
+	}
+
+	return ids
+}
+
+// environment validates a reference to an environment of the organization.
+// Any environment is accepted if the organization does not declare its environments.
+func (r *organizationReferences) environment(name string) error {
+	if r.environments == nil || slices.Contains(r.environments, name) {
+		return nil
+	}
+
+	return fmt.Errorf("referenced environment %s is not declared by the organization", name)
+}
+
+// tag validates a tag used by a feature.
+// Any tag is accepted if the organization does not require declared tags.
+func (r *organizationReferences) tag(name string) error {
+	if r.tags == nil || slices.Contains(r.tags, name) {
+		return nil
+	}
+
+	return fmt.Errorf("tag %s is not declared by a GrowthbookTag of the organization", name)
+}
+
+// role validates a reference to a built-in or custom role of the organization
+func (r *organizationReferences) role(name string) error {
+	if slices.Contains(r.roles, name) {
+		return nil
+	}
+
+	return fmt.Errorf("role %q is neither a built-in nor a custom role of the organization", name)
+}
+
+// namespace validates an experiment namespace, its range must be within [0,1].
+// Any namespace is accepted if the organization does not declare its namespaces.
+func (r *organizationReferences) namespace(namespace *v1beta1.NamespaceValue) error {
+	if namespace == nil || !namespace.Enabled {
+		return nil
+	}
+
+	if r.namespaces != nil && !slices.Contains(r.namespaces, namespace.Name) {
+		return fmt.Errorf("referenced namespace %s is not declared by the organization", namespace.Name)
+	}
+
+	_, _, err := parseNamespaceRange(*namespace)
+	return err
+}
+
+// namespaceConflict validates that the namespace ranges used by the running experiments of a resource
+// do not overlap with the ranges used by running experiments of other resources
+func (r *organizationReferences) namespaceConflict(resource string) error {
+	for _, allocation := range r.namespaceAllocations {
+		if allocation.resource != resource {
+			continue
+		}
+
+		for _, other := range r.namespaceAllocations {
+			if other.resource == resource || other.namespace != allocation.namespace {
+				continue
+			}
+
+			if allocation.environment != "" && other.environment != "" && allocation.environment != other.environment {
+				continue
+			}
+
+			if allocation.start < other.end && other.start < allocation.end {
+				return fmt.Errorf("range [%g, %g] of namespace %s overlaps with the range [%g, %g] used by %s",
+					allocation.start, allocation.end, allocation.namespace, other.start, other.end, other.resource)
+			}
+		}
+	}
+
+	return nil
+}
+
+// unknownAttributes returns the given attributes which are not declared in the attribute schema of the organization
+func (r *organizationReferences) unknownAttributes(attributes []string) []string {
+	if r.attributes == nil {
+		return nil
+	}
+
+	var unknown []string
+	for _, attribute := range attributes {
+		if !slices.Contains(r.attributes, attribute) && !slices.Contains(unknown, attribute) {
+			unknown = append(unknown, attribute)
+		}
+	}
+
+	slices.Sort(unknown)
+	return unknown
+}
+
+// documentResource is a resource which renders a growthbook document
+type documentResource interface {
+	client.Object
+	GetDocumentStatus() *v1beta1.DocumentStatus
+}
+
+// document is a growthbook document rendered from a resource
+type document struct {
+	resource     documentResource
+	organization string
+	id           string
+	body         interface{}
+	diff         func() ([]string, error)
+	update       func() error
+	// meta reads the document metadata once the transaction has been committed, the session context has ended by then
+	meta func(ctx context.Context) (growthbook.DocumentMeta, error)
+	// unknownAttributes lists the attributes used by the document which are not declared by the organization
+	unknownAttributes []string
+	// rotation is the value of the rotate annotation the document has been rotated for
+	rotation string
+}
+
+// documentResult is the outcome of applying a document
+type documentResult struct {
+	document
+	// applied is true if the document has been written
+	applied bool
+	// drifted holds the drifted fields which have not been reverted
+	drifted []string
+	err     error
+}
+
 // MongoDBProvider returns a storage.Database for MongoDB
 func MongoDBProvider(ctx context.Context, instance v1beta1.GrowthbookInstance, username, password string) (storage.Disconnector, storage.Database, error) {
 	opts := options.Client().ApplyURI(instance.Spec.MongoDB.URI)
@@ -99,6 +525,14 @@ type GrowthbookInstanceReconciler struct {
 	Scheme           *runtime.Scheme
 	Recorder         record.EventRecorder
 	DatabaseProvider func(ctx context.Context, instance v1beta1.GrowthbookInstance, username, password string) (storage.Disconnector, storage.Database, error)
+	// Notifier sends notifications to webhooks and the growthbook proxy, no notifications are sent if nil
+	Notifier *growthbook.Notifier
+	// HTTPClient is used to call the growthbook API
+	HTTPClient *http.Client
+
+	pendingMu sync.Mutex
+	// pending holds the changes which could not be notified by instance
+	pending map[types.NamespacedName]growthbook.ChangeSet
 }
 
 type GrowthbookInstanceReconcilerOptions struct {
@@ -120,6 +554,10 @@ func (r *GrowthbookInstanceReconciler) SetupWithManager(mgr ctrl.Manager, opts G
 				}
 			}
 
+			if instance.Spec.EncryptionKeySecret != nil {
+				keys = append(keys, fmt.Sprintf("%s/%s", instance.GetNamespace(), instance.Spec.EncryptionKeySecret.Name))
+			}
+
 			var users v1beta1.GrowthbookUserList
 			selector, err := metav1.LabelSelectorAsSelector(instance.Spec.ResourceSelector)
 			if err != nil {
@@ -158,6 +596,86 @@ func (r *GrowthbookInstanceReconciler) SetupWithManager(mgr ctrl.Manager, opts G
 				keys = append(keys, fmt.Sprintf("%s/%s", instance.GetNamespace(), client.Spec.TokenSecret.Name))
 			}
 
+			// Secrets of api keys are indexed to restore them if they are changed
+			var apiKeys v1beta1.GrowthbookAPIKeyList
+			err = r.Client.List(context.TODO(), &apiKeys, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+			if err != nil {
+				return keys
+			}
+
+			for _, apiKey := range apiKeys.Items {
+				if apiKey.Spec.TokenSecret == nil {
+					continue
+				}
+
+				keys = append(keys, fmt.Sprintf("%s/%s", instance.GetNamespace(), apiKey.Spec.TokenSecret.Name))
+			}
+
+			// Connection params of data sources are read from secrets
+			var dataSources v1beta1.GrowthbookDataSourceList
+			err = r.Client.List(context.TODO(), &dataSources, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+			if err != nil {
+				return keys
+			}
+
+			for _, dataSource := range dataSources.Items {
+				if dataSource.Spec.ParamsSecret == nil {
+					continue
+				}
+
+				keys = append(keys, fmt.Sprintf("%s/%s", instance.GetNamespace(), dataSource.Spec.ParamsSecret.Name))
+			}
+
+			return keys
+		},
+	); err != nil {
+		return err
+	}
+
+	// Index the GrowthbookInstance by the ConfigMap references of its saved groups
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.GrowthbookInstance{}, configMapIndexKey,
+		func(o client.Object) []string {
+			instance := o.(*v1beta1.GrowthbookInstance)
+			keys := []string{}
+
+			var savedGroups v1beta1.GrowthbookSavedGroupList
+			selector, err := metav1.LabelSelectorAsSelector(instance.Spec.ResourceSelector)
+			if err != nil {
+				return keys
+			}
+
+			err = r.Client.List(context.TODO(), &savedGroups, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+			if err != nil {
+				return keys
+			}
+
+			for _, savedGroup := range savedGroups.Items {
+				if savedGroup.Spec.ValuesFrom == nil {
+					continue
+				}
+
+				keys = append(keys, fmt.Sprintf("%s/%s", instance.GetNamespace(), savedGroup.Spec.ValuesFrom.Name))
+			}
+
+			return keys
+		},
+	); err != nil {
+		return err
+	}
+
+	// Index the GrowthbookInstance by the resources found in its inventory
+	if err := mgr.GetFieldIndexer().IndexField(context.TODO(), &v1beta1.GrowthbookInstance{}, inventoryIndexKey,
+		func(o client.Object) []string {
+			instance := o.(*v1beta1.GrowthbookInstance)
+			keys := []string{}
+
+			for _, entry := range instance.Status.Inventory {
+				key := fmt.Sprintf("%s/%s", instance.GetNamespace(), entry.Name)
+				if !slices.Contains(keys, key) {
+					keys = append(keys, key)
+				}
+			}
+
 			return keys
 		},
 	); err != nil {
@@ -172,26 +690,108 @@ func (r *GrowthbookInstanceReconciler) SetupWithManager(mgr ctrl.Manager, opts G
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeByField(secretIndexKey)),
 		).
+		Watches(
+			&corev1.ConfigMap{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeByField(configMapIndexKey)),
+		).
 		Watches(
 			&v1beta1.GrowthbookUser{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
 		).
 		Watches(
 			&v1beta1.GrowthbookOrganization{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
 		).
 		Watches(
 			&v1beta1.GrowthbookClient{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
 		).
 		Watches(
 			&v1beta1.GrowthbookFeature{},
 			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookProject{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookSavedGroup{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookExperiment{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookAttribute{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookTag{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookTeam{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookArchetype{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookDataSource{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookMetric{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookFactTable{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookFactMetric{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookSegment{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookDimension{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			builder.WithPredicates(documentResourcePredicate),
+		).
+		Watches(
+			&v1beta1.GrowthbookAPIKey{},
+			handler.EnqueueRequestsFromMapFunc(r.requestsForChangeBySelector),
+			// Api keys are rotated using an annotation
+			builder.WithPredicates(predicate.Or(documentResourcePredicate, predicate.AnnotationChangedPredicate{})),
 		).
 		WithOptions(controller.Options{MaxConcurrentReconciles: opts.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
+// documentResourcePredicate ignores status updates of the resources the controller writes itself
+var documentResourcePredicate = predicate.Or(predicate.GenerationChangedPredicate{}, predicate.LabelChangedPredicate{})
+
 func (r *GrowthbookInstanceReconciler) requestsForChangeByField(field string) handler.MapFunc {
 	return func(ctx context.Context, o client.Object) []reconcile.Request {
 		var list v1beta1.GrowthbookInstanceList
@@ -225,6 +825,13 @@ func (r *GrowthbookInstanceReconciler) requestsForChangeBySelector(ctx context.C
 		}
 	}
 
+	// Resources which do not match the selector anymore need to be pruned by the instance which applied them
+	for _, req := range r.requestsForChangeByField(inventoryIndexKey)(ctx, o) {
+		if !slices.Contains(reqs, req) {
+			reqs = append(reqs, req)
+		}
+	}
+
 	return reqs
 }
 
@@ -239,6 +846,8 @@ func (r *GrowthbookInstanceReconciler) Reconcile(ctx context.Context, req ctrl.R
 	err := r.Client.Get(ctx, req.NamespacedName, &instance)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
+			r.pendingChanges(req.NamespacedName)
+
 			// Request object not found, could have been deleted after reconcile request.
 			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
 			// Return and don't requeue
@@ -280,7 +889,13 @@ func (r *GrowthbookInstanceReconciler) Reconcile(ctx context.Context, req ctrl.R
 	if err != nil {
 		r.Recorder.Event(&instance, "Normal", "error", err.Error())
 		res = ctrl.Result{Requeue: true}
-		instance = v1beta1.GrowthbookInstanceNotReady(instance, v1beta1.FailedReason, err.Error())
+
+		reason := v1beta1.FailedReason
+		if errors.Is(err, errConflict) || errors.Is(err, growthbook.ErrConflict) {
+			reason = v1beta1.ConflictReason
+		}
+
+		instance = v1beta1.GrowthbookInstanceNotReady(instance, reason, err.Error())
 	} else {
 		if !instance.DeletionTimestamp.IsZero() {
 			if err := r.removeFinalizer(ctx, v1beta1.Finalizer, metav1.PartialObjectMetadata{TypeMeta: instance.TypeMeta, ObjectMeta: instance.ObjectMeta}); err != nil {
@@ -341,31 +956,355 @@ func (r *GrowthbookInstanceReconciler) reconcile(ctx context.Context, instance v
 		}
 	}()
 
+	state := &reconcileState{
+		previousInventory: instance.Status.Inventory,
+	}
+
 	instance.Status.SubResourceCatalog = []v1beta1.ResourceReference{}
+	instance.Status.Inventory = []v1beta1.InventoryEntry{}
+	instance.Status.DriftedResources = nil
 
-	instance, err = r.reconcileUsers(ctx, instance, db)
+	instance, err = r.reconcileResources(ctx, instance, state, db)
 	if err != nil {
-		return instance, fmt.Errorf("failed reconciling users: %w", err)
+		// Documents from the previous inventory are kept until the next successful reconciliation
+		for _, entry := range state.previousInventory {
+			instance = addInventoryEntry(instance, entry)
+		}
+	} else {
+		instance, err = r.pruneInventory(ctx, instance, state, db)
+		if err != nil {
+			err = fmt.Errorf("failed pruning inventory: %w", err)
+		}
 	}
 
-	instance, orgs, err := r.reconcileOrganizations(ctx, instance, db)
-	if err != nil {
+	// Some documents might have been written even if the reconciliation failed
+	for _, changes := range []growthbook.ChangeSet{state.changes, state.stale} {
+		if flushErr := changes.Flush(ctx, db); flushErr != nil && err == nil {
+			err = fmt.Errorf("failed invalidating sdk payloads: %w", flushErr)
+		}
+	}
+
+	// Changes which could not be notified earlier are retried with the changes of this reconciliation
+	if r.Notifier != nil {
+		key := types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name}
+		changes := r.pendingChanges(key)
+		changes.Merge(state.changes)
+
+		failed, notifyErr := r.notify(ctx, instance, changes, db)
+		r.keepPendingChanges(key, failed)
+		if notifyErr != nil && err == nil {
+			err = fmt.Errorf("failed sending notifications: %w", notifyErr)
+		}
+	}
+
+	if statusErr := r.patchDocumentStatuses(ctx, instance, state); statusErr != nil && err == nil {
+		err = fmt.Errorf("failed updating resource status: %w", statusErr)
+	}
+
+	return instance, err
+}
+
+// notify emulates the side effects growthbook triggers after changes.
+// SDK webhooks and the growthbook proxy are called for all sdk connections with invalidated payloads,
+// event webhooks are called for all changed features.
+// The changes which could not be notified are returned and retried during the next reconciliation.
+func (r *GrowthbookInstanceReconciler) notify(ctx context.Context, instance v1beta1.GrowthbookInstance, changes growthbook.ChangeSet, db storage.Database) (growthbook.ChangeSet, error) {
+	now := time.Now()
+	var failed growthbook.ChangeSet
+	var notifyErr error
+
+	fail := func(err error) {
+		if notifyErr == nil {
+			notifyErr = err
+		}
+	}
+
+	for org, envs := range changes.Environments() {
+		sdkconnections, err := growthbook.FindSDKConnections(ctx, org, db)
+		if err != nil {
+			failed.Invalidate(org, envs...)
+			fail(err)
+			continue
+		}
+
+		webhooks, err := growthbook.FindSDKWebhooks(ctx, org, db)
+		if err != nil {
+			failed.Invalidate(org, envs...)
+			fail(err)
+			continue
+		}
+
+		for _, sdkconnection := range sdkconnections {
+			if !slices.Contains(envs, sdkconnection.Environment) {
+				continue
+			}
+
+			var payload []byte
+			if instance.Spec.APIHost != "" {
+				payload, err = growthbook.FetchSDKPayload(ctx, r.httpClient(), instance.Spec.APIHost, sdkconnection)
+				if err != nil {
+					failed.Invalidate(org, sdkconnection.Environment)
+					fail(err)
+					continue
+				}
+			}
+
+			if sdkconnection.Proxy.Enabled && sdkconnection.Proxy.Host != "" && payload != nil {
+				r.Notifier.Enqueue(growthbook.NewProxyNotification(sdkconnection, payload))
+			}
+
+			for _, webhook := range webhooks {
+				if webhook.Matches(sdkconnection) {
+					r.Notifier.Enqueue(growthbook.NewSDKWebhookNotification(webhook, sdkconnection, payload, now))
+				}
+			}
+		}
+	}
+
+	webhooks := make(map[string][]growthbook.EventWebhook)
+	for _, change := range changes.FeatureChanges() {
+		if _, ok := webhooks[change.Organization]; !ok {
+			orgWebhooks, err := growthbook.FindEventWebhooks(ctx, change.Organization, db)
+			if err != nil {
+				failed.FeatureChanged(change)
+				fail(err)
+				continue
+			}
+
+			webhooks[change.Organization] = orgWebhooks
+		}
+
+		var notifications []growthbook.Notification
+		var err error
+		for _, webhook := range webhooks[change.Organization] {
+			if !webhook.Subscribed(change.Event) {
+				continue
+			}
+
+			var notification growthbook.Notification
+			notification, err = growthbook.NewEventNotification(webhook, change, now)
+			if err != nil {
+				break
+			}
+
+			notifications = append(notifications, notification)
+		}
+
+		// A change is only notified once all its notifications could be built, otherwise webhooks would receive it twice
+		if err != nil {
+			failed.FeatureChanged(change)
+			fail(err)
+			continue
+		}
+
+		for _, notification := range notifications {
+			r.Notifier.Enqueue(notification)
+		}
+	}
+
+	return failed, notifyErr
+}
+
+// pendingChanges removes and returns the changes of an instance which have not been notified yet
+func (r *GrowthbookInstanceReconciler) pendingChanges(instance types.NamespacedName) growthbook.ChangeSet {
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	changes := r.pending[instance]
+	delete(r.pending, instance)
+	return changes
+}
+
+// keepPendingChanges stores changes of an instance which could not be notified
+func (r *GrowthbookInstanceReconciler) keepPendingChanges(instance types.NamespacedName, changes growthbook.ChangeSet) {
+	if len(changes.Environments()) == 0 && len(changes.FeatureChanges()) == 0 {
+		return
+	}
+
+	r.pendingMu.Lock()
+	defer r.pendingMu.Unlock()
+
+	if r.pending == nil {
+		r.pending = make(map[types.NamespacedName]growthbook.ChangeSet)
+	}
+
+	pending := r.pending[instance]
+	pending.Merge(changes)
+	r.pending[instance] = pending
+}
+
+func (r *GrowthbookInstanceReconciler) httpClient() *http.Client {
+	if r.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return r.HTTPClient
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileResources(ctx context.Context, instance v1beta1.GrowthbookInstance, state *reconcileState, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	// Users are not written within a transaction, their outcomes and side effects apply right away
+	users := &reconcileState{previousInventory: state.previousInventory}
+	instance, err := r.reconcileUsers(ctx, instance, users, db)
+	if effectsErr := r.applyEffects(ctx, instance, users, db); effectsErr != nil && err == nil {
+		err = effectsErr
+	}
+
+	state.commit(users)
+
+	if err != nil {
+		return instance, fmt.Errorf("failed reconciling users: %w", err)
+	}
+
+	instance, orgs, err := r.reconcileOrganizations(ctx, instance, db)
+	if err != nil {
 		return instance, fmt.Errorf("failed reconciling organizations: %w", err)
 	}
 
+	// All documents of an organization are written within a single transaction if supported by the database
 	for _, org := range orgs {
-		instance, err = r.reconcileFeatures(ctx, instance, org, db)
+		// Keys are generated once, a retried transaction must write the same key
+		rotations, err := r.apiKeyRotations(ctx, instance, org)
 		if err != nil {
-			return instance, fmt.Errorf("failed reconciling features: %w", err)
+			return instance, fmt.Errorf("failed generating api keys: %w", err)
 		}
 
-		instance, err = r.reconcileClients(ctx, instance, org, db)
+		var current v1beta1.GrowthbookInstance
+		var attempt *reconcileState
+		err = db.WithTransaction(ctx, func(ctx context.Context) error {
+			// The transaction might be retried, start over from the state before the organization
+			current = instance
+			attempt = &reconcileState{previousInventory: state.previousInventory, rotations: rotations}
+			refs := &organizationReferences{}
+
+			// Projects are reconciled first as they can be referenced by the organization
+			var err error
+			current, err = r.reconcileProjects(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling projects: %w", err)
+			}
+
+			current, err = r.reconcileOrganization(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling organizations: %w", err)
+			}
+
+			current, err = r.reconcileTeams(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling teams: %w", err)
+			}
+
+			current, err = r.reconcileTags(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling tags: %w", err)
+			}
+
+			current, err = r.reconcileAttributes(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling attributes: %w", err)
+			}
+
+			current, err = r.reconcileArchetypes(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling archetypes: %w", err)
+			}
+
+			current, err = r.reconcileDataSources(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling data sources: %w", err)
+			}
+
+			current, err = r.reconcileSegments(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling segments: %w", err)
+			}
+
+			current, err = r.reconcileDimensions(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling dimensions: %w", err)
+			}
+
+			current, err = r.reconcileMetrics(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling metrics: %w", err)
+			}
+
+			current, err = r.reconcileFactTables(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling fact tables: %w", err)
+			}
+
+			current, err = r.reconcileFactMetrics(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling fact metrics: %w", err)
+			}
+
+			current, err = r.reconcileSavedGroups(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling saved groups: %w", err)
+			}
+
+			current, err = r.reconcileExperiments(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling experiments: %w", err)
+			}
+
+			current, err = r.reconcileFeatures(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling features: %w", err)
+			}
+
+			current, err = r.reconcileClients(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling clients: %w", err)
+			}
+
+			current, err = r.reconcileAPIKeys(ctx, current, org, attempt, refs, db)
+			if err != nil {
+				return fmt.Errorf("failed reconciling api keys: %w", err)
+			}
+
+			return nil
+		})
+
 		if err != nil {
-			return instance, fmt.Errorf("failed reconciling clients: %w", err)
+			state.abort(attempt)
+			return instance, err
 		}
+
+		effectsErr := r.applyEffects(ctx, current, attempt, db)
+		state.commit(attempt)
+		if effectsErr != nil {
+			return current, effectsErr
+		}
+
+		instance = current
 	}
 
-	return instance, err
+	return instance, nil
+}
+
+// applyEffects applies the side effects of a committed transaction attempt.
+// Failures to write an api key secret are recorded as outcome of the api key.
+func (r *GrowthbookInstanceReconciler) applyEffects(ctx context.Context, instance v1beta1.GrowthbookInstance, attempt *reconcileState, db storage.Database) error {
+	for _, e := range attempt.events {
+		r.Recorder.Event(e.object, e.eventtype, e.reason, e.message)
+	}
+
+	for _, token := range attempt.tokens {
+		if err := r.writeAPIKeySecret(ctx, token.apiKey, token.doc.body.(growthbook.APIKey), db); err != nil {
+			attempt.record(documentResult{document: token.doc, err: err})
+			return err
+		}
+	}
+
+	finalizerName := fmt.Sprintf("%s/%s.%s", v1beta1.Finalizer, instance.Name, instance.Namespace)
+	for _, obj := range attempt.releases {
+		if err := r.removeFinalizer(ctx, finalizerName, obj); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func (r *GrowthbookInstanceReconciler) reconcileOrganizations(ctx context.Context, instance v1beta1.GrowthbookInstance, db storage.Database) (v1beta1.GrowthbookInstance, []v1beta1.GrowthbookOrganization, error) {
@@ -394,109 +1333,1438 @@ func (r *GrowthbookInstanceReconciler) reconcileOrganizations(ctx context.Contex
 		}
 	}
 
-	for _, org := range orgs.Items {
-		o := growthbook.Organization{}
-		o.FromV1beta1(org)
+	return instance, orgs.Items, nil
+}
 
-		for _, binding := range org.Spec.Users {
-			var users v1beta1.GrowthbookUserList
-			selector, err := metav1.LabelSelectorAsSelector(binding.Selector)
-			if err != nil {
-				return instance, nil, err
-			}
+func (r *GrowthbookInstanceReconciler) reconcileOrganization(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	o := growthbook.Organization{}
+	o.FromV1beta1(org)
+
+	doc := document{
+		resource: &org,
+		id:       o.ID,
+		diff:     func() ([]string, error) { return growthbook.DiffOrganization(ctx, o, db) },
+		update:   func() error { return growthbook.UpdateOrganization(ctx, o, db) },
+		meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+			return growthbook.GetOrganizationMeta(ctx, o, db)
+		},
+	}
+
+	if org.Spec.Environments != nil {
+		refs.environments = []string{}
+	}
 
-			err = r.Client.List(ctx, &users, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	for i, env := range org.Spec.Environments {
+		for _, name := range env.Projects {
+			project, err := refs.project(name)
 			if err != nil {
-				return instance, nil, err
+				state.record(documentResult{document: doc, err: err})
+				return instance, err
 			}
 
-			for _, user := range users.Items {
-				o.Members = append(o.Members, growthbook.OrganizationMember{
-					ID:   user.GetID(),
-					Role: binding.Role,
-				})
-			}
+			o.Environments[i].Projects = append(o.Environments[i].Projects, project)
 		}
 
-		if org.DeletionTimestamp.IsZero() && instance.DeletionTimestamp.IsZero() {
-			if err := growthbook.UpdateOrganization(ctx, o, db); err != nil {
-				return instance, nil, err
-			}
-		} else {
-			if instance.Spec.Prune {
-				if err := growthbook.DeleteOrganization(ctx, o, db); err != nil {
-					return instance, nil, err
-				}
-			}
+		refs.environments = append(refs.environments, env.Name)
+	}
 
-			if err := r.removeFinalizer(ctx, finalizerName, metav1.PartialObjectMetadata{TypeMeta: org.TypeMeta, ObjectMeta: org.ObjectMeta}); err != nil {
-				return instance, nil, err
-			}
+	if org.Spec.Namespaces != nil {
+		refs.namespaces = []string{}
+	}
+
+	for _, namespace := range org.Spec.Namespaces {
+		refs.namespaces = append(refs.namespaces, namespace.Name)
+	}
+
+	refs.roles = slices.Clone(growthbook.BuiltinRoles)
+	for _, role := range org.Spec.CustomRoles {
+		if slices.Contains(refs.roles, role.ID) {
+			err := fmt.Errorf("custom role %s is declared more than once or conflicts with a built-in role", role.ID)
+			state.record(documentResult{document: doc, err: err})
+			return instance, err
 		}
+
+		refs.roles = append(refs.roles, role.ID)
 	}
 
-	return instance, orgs.Items, nil
+	for _, binding := range org.Spec.Users {
+		if err := refs.role(binding.Role); err != nil {
+			state.record(documentResult{document: doc, err: err})
+			return instance, err
+		}
+
+		var users v1beta1.GrowthbookUserList
+		selector, err := metav1.LabelSelectorAsSelector(binding.Selector)
+		if err != nil {
+			return instance, err
+		}
+
+		err = r.Client.List(ctx, &users, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return instance, err
+		}
+
+		for _, user := range users.Items {
+			o.Members = append(o.Members, growthbook.OrganizationMember{
+				ID:   user.GetID(),
+				Role: binding.Role,
+			})
+		}
+	}
+
+	var teams v1beta1.GrowthbookTeamList
+	if err := r.listOrganizationResources(ctx, instance, org, &teams); err != nil {
+		return instance, err
+	}
+
+	for _, team := range teams.Items {
+		if !team.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		members, err := r.getTeamMembers(ctx, team, db)
+		if err != nil {
+			return instance, err
+		}
+
+		for _, id := range members {
+			o.Members = addTeamMember(o.Members, id, team.GetID())
+		}
+	}
+
+	if org.DeletionTimestamp.IsZero() && instance.DeletionTimestamp.IsZero() {
+		doc.body = o
+		return r.applyDocument(ctx, instance, state, doc)
+	}
+
+	if instance.GetDeletionPolicy(org.Spec.DeletionPolicy) == v1beta1.DeletionPolicyDelete {
+		if err := growthbook.DeleteOrganization(ctx, o, db); err != nil {
+			return instance, err
+		}
+	}
+
+	state.release(metav1.PartialObjectMetadata{TypeMeta: org.TypeMeta, ObjectMeta: org.ObjectMeta})
+	return instance, nil
 }
 
-func (r *GrowthbookInstanceReconciler) reconcileFeatures(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, db storage.Database) (v1beta1.GrowthbookInstance, error) {
-	var features v1beta1.GrowthbookFeatureList
+// getTeamMembers returns the user ids of all members of a team.
+// Members selected by email are skipped until the user exists in growthbook.
+func (r *GrowthbookInstanceReconciler) getTeamMembers(ctx context.Context, team v1beta1.GrowthbookTeam, db storage.Database) ([]string, error) {
+	var members []string
+
+	if team.Spec.Users != nil {
+		var users v1beta1.GrowthbookUserList
+		selector, err := metav1.LabelSelectorAsSelector(team.Spec.Users)
+		if err != nil {
+			return nil, err
+		}
+
+		err = r.Client.List(ctx, &users, client.InNamespace(team.Namespace), client.MatchingLabelsSelector{Selector: selector})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, user := range users.Items {
+			members = append(members, user.GetID())
+		}
+	}
+
+	for _, email := range team.Spec.Emails {
+		user, err := growthbook.FindUserByEmail(ctx, email, db)
+		if err != nil || user.ID == "" {
+			continue
+		}
+
+		members = append(members, user.ID)
+	}
+
+	return members, nil
+}
+
+// addTeamMember adds a team to an organization member.
+// Users which are not a member of the organization yet are added without any access besides the one granted by their teams.
+func addTeamMember(members []growthbook.OrganizationMember, id, team string) []growthbook.OrganizationMember {
+	for i, member := range members {
+		if member.ID != id {
+			continue
+		}
+
+		if !slices.Contains(member.Teams, team) {
+			members[i].Teams = append(members[i].Teams, team)
+		}
+
+		return members
+	}
+
+	return append(members, growthbook.OrganizationMember{
+		ID:    id,
+		Role:  growthbook.TeamMemberRole,
+		Teams: []string{team},
+	})
+}
+
+// listOrganizationResources lists all resources selected by both the organization and the instance
+func (r *GrowthbookInstanceReconciler) listOrganizationResources(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, list client.ObjectList) error {
 	selector, err := metav1.LabelSelectorAsSelector(org.Spec.ResourceSelector)
 	if err != nil {
-		return instance, err
+		return err
 	}
 
-	finalizerName := fmt.Sprintf("%s/%s.%s", v1beta1.Finalizer, instance.Name, instance.Namespace)
 	instanceSelector, err := metav1.LabelSelectorAsSelector(instance.Spec.ResourceSelector)
 	if err != nil {
-		return instance, err
+		return err
 	}
 
 	req, _ := instanceSelector.Requirements()
-	selector.Add(req...)
+	selector = selector.Add(req...)
+
+	return r.Client.List(ctx, list, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+}
+
+// documentResourcePointer is the pointer type of a resource which renders a growthbook document
+type documentResourcePointer[T any] interface {
+	*T
+	documentResource
+	metav1.ObjectMetaAccessor
+}
+
+// documentKind describes how the resources of a kind are reconciled into the documents of an organization
+type documentKind[T any, P documentResourcePointer[T]] struct {
+	// conflict names the claimed id in conflict errors
+	conflict string
+	// list returns the resources selected by the organization and the instance
+	list func() ([]T, error)
+	// id returns the growthbook id claimed by a resource
+	id func(resource P) string
+	// deletionPolicy returns the deletion policy declared by a resource
+	deletionPolicy func(resource P) v1beta1.DeletionPolicy
+	// claim registers a resource claiming its id, references to it are resolvable afterwards
+	claim func(resource P)
+	// retain reports whether the document of a deleted resource is still applied
+	retain func(resource P) bool
+	// orphan reports whether a resource is released without applying or removing its document
+	orphan func(resource P) bool
+	// convert renders the document of a resource, the document holds the resource, organization and id even on error
+	convert func(resource P) (document, error)
+	// applied is called once the document of a resource has been applied
+	applied func(resource P, doc document) error
+}
+
+// claims reports whether a resource claims its id within the organization
+func (k documentKind[T, P]) claims(resource P) bool {
+	return resource.GetDeletionTimestamp().IsZero() || (k.retain != nil && k.retain(resource))
+}
+
+// reconcileDocuments applies the documents of all resources of a kind within an organization.
+// Documents of deleted resources are removed according to their deletion policy unless another resource claims the same id.
+func reconcileDocuments[T any, P documentResourcePointer[T]](ctx context.Context, r *GrowthbookInstanceReconciler, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, db storage.Database, kind documentKind[T, P]) (v1beta1.GrowthbookInstance, error) {
+	finalizerName := fmt.Sprintf("%s/%s.%s", v1beta1.Finalizer, instance.Name, instance.Namespace)
 
-	err = r.Client.List(ctx, &features, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
+	resources, err := kind.list()
 	if err != nil {
 		return instance, err
 	}
 
 	if instance.DeletionTimestamp.IsZero() {
-		for _, feature := range features.Items {
-			if err := r.addFinalizer(ctx, finalizerName, metav1.PartialObjectMetadata{TypeMeta: feature.TypeMeta, ObjectMeta: feature.ObjectMeta}); err != nil {
+		for i := range resources {
+			resource := P(&resources[i])
+			if err := r.addFinalizer(ctx, finalizerName, objectMetadata(resource)); err != nil {
 				return instance, err
 			}
 
-			if feature.DeletionTimestamp.IsZero() {
-				instance = updateResourceCatalog(instance, &feature)
+			if resource.GetDeletionTimestamp().IsZero() {
+				instance = updateResourceCatalog(instance, resource)
 			}
 		}
 	}
 
-	for _, feature := range features.Items {
-		f := growthbook.Feature{
-			Owner:        owner,
-			Organization: org.GetID(),
+	claimed := make(map[string]string)
+	for i := range resources {
+		resource := P(&resources[i])
+		if !kind.claims(resource) {
+			continue
 		}
 
-		f.FromV1beta1(feature)
+		id := kind.id(resource)
+		if name, ok := claimed[id]; ok {
+			err := fmt.Errorf("%w: %s %s in organization %s is claimed by both %s and %s", errConflict, kind.conflict, id, org.GetID(), name, resource.GetName())
+			state.record(documentResult{
+				document: document{resource: resource, organization: org.GetID(), id: id},
+				err:      err,
+			})
 
-		if feature.DeletionTimestamp.IsZero() && instance.DeletionTimestamp.IsZero() {
-			if err := growthbook.UpdateFeature(ctx, f, db); err != nil {
-				return instance, err
+			return instance, err
+		}
+
+		claimed[id] = resource.GetName()
+		if kind.claim != nil {
+			kind.claim(resource)
+		}
+	}
+
+	for i := range resources {
+		resource := P(&resources[i])
+		id := kind.id(resource)
+
+		switch {
+		case kind.orphan != nil && kind.orphan(resource):
+			// The document is neither applied nor removed, only the finalizer is released
+		case kind.claims(resource) && instance.DeletionTimestamp.IsZero():
+			doc, err := kind.convert(resource)
+			if err != nil {
+				state.record(documentResult{document: doc, err: err})
+				return instance, err
 			}
-		} else {
-			if instance.Spec.Prune {
-				if err := growthbook.DeleteFeature(ctx, f, db); err != nil {
+
+			instance, err = r.applyDocument(ctx, instance, state, doc)
+			if err != nil {
+				return instance, err
+			}
+
+			if kind.applied != nil {
+				if err := kind.applied(resource, doc); err != nil {
+					state.record(documentResult{document: doc, err: err})
 					return instance, err
 				}
 			}
 
-			if err := r.removeFinalizer(ctx, finalizerName, metav1.PartialObjectMetadata{TypeMeta: feature.TypeMeta, ObjectMeta: feature.ObjectMeta}); err != nil {
-				return instance, err
+			continue
+		default:
+			// Another resource took over the id, the document must not be removed
+			if _, ok := claimed[id]; !ok || !instance.DeletionTimestamp.IsZero() {
+				entry := v1beta1.InventoryEntry{
+					ResourceReference: newResourceReference(resource),
+					ID:                id,
+					Organization:      org.GetID(),
+				}
+
+				if err := deleteDocument(ctx, entry, instance.GetDeletionPolicy(kind.deletionPolicy(resource)), db, &state.changes); err != nil {
+					return instance, err
+				}
+			}
+		}
+
+		state.release(objectMetadata(resource))
+	}
+
+	return instance, nil
+}
+
+// objectMetadata returns the type and object metadata of a resource
+func objectMetadata(resource interface {
+	client.Object
+	metav1.ObjectMetaAccessor
+}) metav1.PartialObjectMetadata {
+	meta := metav1.PartialObjectMetadata{
+		ObjectMeta: *resource.GetObjectMeta().(*metav1.ObjectMeta),
+	}
+
+	meta.SetGroupVersionKind(resource.GetObjectKind().GroupVersionKind())
+	return meta
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileProjects(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	refs.projects = make(map[string]string)
+
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookProject, *v1beta1.GrowthbookProject]{
+		conflict: "project id",
+		list: func() ([]v1beta1.GrowthbookProject, error) {
+			var projects v1beta1.GrowthbookProjectList
+			err := r.listOrganizationResources(ctx, instance, org, &projects)
+			return projects.Items, err
+		},
+		id: (*v1beta1.GrowthbookProject).GetID,
+		deletionPolicy: func(project *v1beta1.GrowthbookProject) v1beta1.DeletionPolicy {
+			return project.Spec.DeletionPolicy
+		},
+		claim: func(project *v1beta1.GrowthbookProject) {
+			refs.projects[project.Name] = project.GetID()
+		},
+		convert: func(project *v1beta1.GrowthbookProject) (document, error) {
+			p := growthbook.Project{
+				Organization: org.GetID(),
+			}
+
+			p.FromV1beta1(*project)
+
+			return document{
+				resource:     project,
+				organization: p.Organization,
+				id:           p.ID,
+				body:         p,
+				diff:         func() ([]string, error) { return growthbook.DiffProject(ctx, p, db) },
+				update:       func() error { return growthbook.UpdateProject(ctx, p, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetProjectMeta(ctx, p, db)
+				},
+			}, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileTeams(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookTeam, *v1beta1.GrowthbookTeam]{
+		conflict: "team id",
+		list: func() ([]v1beta1.GrowthbookTeam, error) {
+			var teams v1beta1.GrowthbookTeamList
+			err := r.listOrganizationResources(ctx, instance, org, &teams)
+			return teams.Items, err
+		},
+		id: (*v1beta1.GrowthbookTeam).GetID,
+		deletionPolicy: func(team *v1beta1.GrowthbookTeam) v1beta1.DeletionPolicy {
+			return team.Spec.DeletionPolicy
+		},
+		convert: func(team *v1beta1.GrowthbookTeam) (document, error) {
+			t := growthbook.Team{
+				Organization: org.GetID(),
+			}
+
+			t.FromV1beta1(*team)
+
+			doc := document{
+				resource:     team,
+				organization: t.Organization,
+				id:           t.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffTeam(ctx, t, db) },
+				update:       func() error { return growthbook.UpdateTeam(ctx, t, db) },
+				meta:         func(ctx context.Context) (growthbook.DocumentMeta, error) { return growthbook.GetTeamMeta(ctx, t, db) },
+			}
+
+			if err := validateTeamRoles(*team, refs); err != nil {
+				return doc, err
+			}
+
+			if err := validateTeamEnvironments(*team, refs); err != nil {
+				return doc, err
+			}
+
+			for i, projectRole := range team.Spec.ProjectRoles {
+				project, err := refs.project(projectRole.Project)
+				if err != nil {
+					return doc, err
+				}
+
+				t.ProjectRoles[i].Project = project
+			}
+
+			doc.body = t
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileArchetypes(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookArchetype, *v1beta1.GrowthbookArchetype]{
+		conflict: "archetype id",
+		list: func() ([]v1beta1.GrowthbookArchetype, error) {
+			var archetypes v1beta1.GrowthbookArchetypeList
+			err := r.listOrganizationResources(ctx, instance, org, &archetypes)
+			return archetypes.Items, err
+		},
+		id: (*v1beta1.GrowthbookArchetype).GetID,
+		deletionPolicy: func(archetype *v1beta1.GrowthbookArchetype) v1beta1.DeletionPolicy {
+			return archetype.Spec.DeletionPolicy
+		},
+		convert: func(archetype *v1beta1.GrowthbookArchetype) (document, error) {
+			a := growthbook.Archetype{
+				Organization: org.GetID(),
+			}
+
+			a.FromV1beta1(*archetype)
+
+			doc := document{
+				resource:     archetype,
+				organization: a.Organization,
+				id:           a.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffArchetype(ctx, a, db) },
+				update:       func() error { return growthbook.UpdateArchetype(ctx, a, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetArchetypeMeta(ctx, a, db)
+				},
+			}
+
+			var attributes map[string]interface{}
+			if err := json.Unmarshal([]byte(a.Attributes), &attributes); err != nil || attributes == nil {
+				return doc, errors.New("attributes must be a JSON object")
+			}
+
+			doc.body = a
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileDataSources(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	refs.dataSources = make(map[string]string)
+
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookDataSource, *v1beta1.GrowthbookDataSource]{
+		conflict: "data source id",
+		list: func() ([]v1beta1.GrowthbookDataSource, error) {
+			var dataSources v1beta1.GrowthbookDataSourceList
+			err := r.listOrganizationResources(ctx, instance, org, &dataSources)
+			return dataSources.Items, err
+		},
+		id: (*v1beta1.GrowthbookDataSource).GetID,
+		deletionPolicy: func(dataSource *v1beta1.GrowthbookDataSource) v1beta1.DeletionPolicy {
+			return dataSource.Spec.DeletionPolicy
+		},
+		claim: func(dataSource *v1beta1.GrowthbookDataSource) {
+			refs.dataSources[dataSource.Name] = dataSource.GetID()
+		},
+		convert: func(dataSource *v1beta1.GrowthbookDataSource) (document, error) {
+			d := growthbook.DataSource{
+				Organization: org.GetID(),
+			}
+
+			d.FromV1beta1(*dataSource)
+
+			doc := document{
+				resource:     dataSource,
+				organization: d.Organization,
+				id:           d.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffDataSource(ctx, d, db) },
+				update:       func() error { return growthbook.UpdateDataSource(ctx, d, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetDataSourceMeta(ctx, d, db)
+				},
+			}
+
+			d.Projects = []string{}
+			for _, name := range dataSource.Spec.Projects {
+				id, err := refs.project(name)
+				if err != nil {
+					return doc, err
+				}
+
+				d.Projects = append(d.Projects, id)
+			}
+
+			if dataSource.Spec.Settings != "" {
+				if err := bson.UnmarshalExtJSON([]byte(dataSource.Spec.Settings), false, &d.Settings); err != nil {
+					return doc, fmt.Errorf("settings must be a JSON object: %w", err)
+				}
+			}
+
+			params, err := r.getDataSourceParams(ctx, *dataSource)
+			if err != nil {
+				return doc, err
+			}
+
+			key, err := r.getEncryptionKey(ctx, instance)
+			if err != nil {
+				return doc, err
+			}
+
+			d.ConnectionParams = params
+			d.EncryptionKey = key
+
+			// The inventory checksum must change if the connection params change, they are only added as keyed hash
+			body := d
+			body.Params, err = paramsChecksum(params, key)
+			if err != nil {
+				return doc, err
+			}
+
+			doc.body = body
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileSegments(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookSegment, *v1beta1.GrowthbookSegment]{
+		conflict: "segment id",
+		list: func() ([]v1beta1.GrowthbookSegment, error) {
+			var segments v1beta1.GrowthbookSegmentList
+			err := r.listOrganizationResources(ctx, instance, org, &segments)
+			return segments.Items, err
+		},
+		id: (*v1beta1.GrowthbookSegment).GetID,
+		deletionPolicy: func(segment *v1beta1.GrowthbookSegment) v1beta1.DeletionPolicy {
+			return segment.Spec.DeletionPolicy
+		},
+		convert: func(segment *v1beta1.GrowthbookSegment) (document, error) {
+			sg := growthbook.Segment{
+				Organization: org.GetID(),
+			}
+
+			sg.FromV1beta1(*segment)
+
+			doc := document{
+				resource:     segment,
+				organization: sg.Organization,
+				id:           sg.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffSegment(ctx, sg, db) },
+				update:       func() error { return growthbook.UpdateSegment(ctx, sg, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetSegmentMeta(ctx, sg, db)
+				},
+			}
+
+			dataSource, err := refs.dataSource(sg.DataSource)
+			if err != nil {
+				return doc, err
+			}
+
+			sg.DataSource = dataSource
+			doc.body = sg
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileDimensions(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookDimension, *v1beta1.GrowthbookDimension]{
+		conflict: "dimension id",
+		list: func() ([]v1beta1.GrowthbookDimension, error) {
+			var dimensions v1beta1.GrowthbookDimensionList
+			err := r.listOrganizationResources(ctx, instance, org, &dimensions)
+			return dimensions.Items, err
+		},
+		id: (*v1beta1.GrowthbookDimension).GetID,
+		deletionPolicy: func(dimension *v1beta1.GrowthbookDimension) v1beta1.DeletionPolicy {
+			return dimension.Spec.DeletionPolicy
+		},
+		convert: func(dimension *v1beta1.GrowthbookDimension) (document, error) {
+			d := growthbook.Dimension{
+				Organization: org.GetID(),
+			}
+
+			d.FromV1beta1(*dimension)
+
+			doc := document{
+				resource:     dimension,
+				organization: d.Organization,
+				id:           d.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffDimension(ctx, d, db) },
+				update:       func() error { return growthbook.UpdateDimension(ctx, d, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetDimensionMeta(ctx, d, db)
+				},
+			}
+
+			dataSource, err := refs.dataSource(d.DataSource)
+			if err != nil {
+				return doc, err
+			}
+
+			d.DataSource = dataSource
+			doc.body = d
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileMetrics(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookMetric, *v1beta1.GrowthbookMetric]{
+		conflict: "metric id",
+		list: func() ([]v1beta1.GrowthbookMetric, error) {
+			var metrics v1beta1.GrowthbookMetricList
+			err := r.listOrganizationResources(ctx, instance, org, &metrics)
+			return metrics.Items, err
+		},
+		id: (*v1beta1.GrowthbookMetric).GetID,
+		deletionPolicy: func(metric *v1beta1.GrowthbookMetric) v1beta1.DeletionPolicy {
+			return metric.Spec.DeletionPolicy
+		},
+		convert: func(metric *v1beta1.GrowthbookMetric) (document, error) {
+			m := growthbook.Metric{
+				Organization: org.GetID(),
+			}
+
+			m.FromV1beta1(*metric)
+
+			doc := document{
+				resource:     metric,
+				organization: m.Organization,
+				id:           m.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffMetric(ctx, m, db) },
+				update:       func() error { return growthbook.UpdateMetric(ctx, m, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetMetricMeta(ctx, m, db)
+				},
+			}
+
+			dataSource, err := refs.dataSource(m.DataSource)
+			if err != nil {
+				return doc, err
+			}
+
+			m.Projects = []string{}
+			for _, name := range metric.Spec.Projects {
+				id, err := refs.project(name)
+				if err != nil {
+					return doc, err
+				}
+
+				m.Projects = append(m.Projects, id)
+			}
+
+			for _, tag := range metric.Spec.Tags {
+				if err := refs.tag(tag); err != nil {
+					return doc, err
+				}
+			}
+
+			if err := validateMetricQuery(*metric); err != nil {
+				return doc, err
+			}
+
+			m.DataSource = dataSource
+			doc.body = m
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileFactTables(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	refs.factTables = make(map[string]factTableReference)
+
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookFactTable, *v1beta1.GrowthbookFactTable]{
+		conflict: "fact table id",
+		list: func() ([]v1beta1.GrowthbookFactTable, error) {
+			var factTables v1beta1.GrowthbookFactTableList
+			err := r.listOrganizationResources(ctx, instance, org, &factTables)
+			return factTables.Items, err
+		},
+		id: (*v1beta1.GrowthbookFactTable).GetID,
+		deletionPolicy: func(factTable *v1beta1.GrowthbookFactTable) v1beta1.DeletionPolicy {
+			return factTable.Spec.DeletionPolicy
+		},
+		claim: func(factTable *v1beta1.GrowthbookFactTable) {
+			refs.factTables[factTable.Name] = newFactTableReference(*factTable)
+		},
+		convert: func(factTable *v1beta1.GrowthbookFactTable) (document, error) {
+			t := growthbook.FactTable{
+				Organization: org.GetID(),
+			}
+
+			t.FromV1beta1(*factTable)
+
+			doc := document{
+				resource:     factTable,
+				organization: t.Organization,
+				id:           t.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffFactTable(ctx, t, db) },
+				update:       func() error { return growthbook.UpdateFactTable(ctx, t, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetFactTableMeta(ctx, t, db)
+				},
+			}
+
+			dataSource, err := refs.dataSource(t.DataSource)
+			if err != nil {
+				return doc, err
+			}
+
+			t.Projects = []string{}
+			for _, name := range factTable.Spec.Projects {
+				id, err := refs.project(name)
+				if err != nil {
+					return doc, err
+				}
+
+				t.Projects = append(t.Projects, id)
+			}
+
+			for _, tag := range factTable.Spec.Tags {
+				if err := refs.tag(tag); err != nil {
+					return doc, err
+				}
+			}
+
+			t.DataSource = dataSource
+			doc.body = t
+			return doc, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileFactMetrics(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookFactMetric, *v1beta1.GrowthbookFactMetric]{
+		conflict: "fact metric id",
+		list: func() ([]v1beta1.GrowthbookFactMetric, error) {
+			var factMetrics v1beta1.GrowthbookFactMetricList
+			err := r.listOrganizationResources(ctx, instance, org, &factMetrics)
+			return factMetrics.Items, err
+		},
+		id: (*v1beta1.GrowthbookFactMetric).GetID,
+		deletionPolicy: func(factMetric *v1beta1.GrowthbookFactMetric) v1beta1.DeletionPolicy {
+			return factMetric.Spec.DeletionPolicy
+		},
+		convert: func(factMetric *v1beta1.GrowthbookFactMetric) (document, error) {
+			m := growthbook.FactMetric{
+				Organization: org.GetID(),
+			}
+
+			m.FromV1beta1(*factMetric)
+
+			doc := document{
+				resource:     factMetric,
+				organization: m.Organization,
+				id:           m.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffFactMetric(ctx, m, db) },
+				update:       func() error { return growthbook.UpdateFactMetric(ctx, m, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetFactMetricMeta(ctx, m, db)
+				},
+			}
+
+			dataSource, err := refs.dataSource(m.DataSource)
+			if err != nil {
+				return doc, err
+			}
+
+			m.Numerator.FactTableID, err = refs.factColumn(factMetric.Spec.DataSource, factMetric.Spec.Numerator)
+			if err != nil {
+				return doc, err
+			}
+
+			if err := validateFactMetricDenominator(*factMetric); err != nil {
+				return doc, err
+			}
+
+			if m.Denominator != nil {
+				m.Denominator.FactTableID, err = refs.factColumn(factMetric.Spec.DataSource, *factMetric.Spec.Denominator)
+				if err != nil {
+					return doc, err
+				}
+			}
+
+			m.Projects = []string{}
+			for _, name := range factMetric.Spec.Projects {
+				id, err := refs.project(name)
+				if err != nil {
+					return doc, err
+				}
+
+				m.Projects = append(m.Projects, id)
+			}
+
+			for _, tag := range factMetric.Spec.Tags {
+				if err := refs.tag(tag); err != nil {
+					return doc, err
+				}
+			}
+
+			if err := validateMetricCap(factMetric.Spec.Cap); err != nil {
+				return doc, err
+			}
+
+			m.DataSource = dataSource
+			doc.body = m
+			return doc, nil
+		},
+	})
+}
+
+// validateMetricQuery validates the query definition of a metric according to its query format
+func validateMetricQuery(metric v1beta1.GrowthbookMetric) error {
+	switch {
+	case metric.Spec.QueryFormat == v1beta1.MetricQueryFormatBuilder && metric.Spec.Table == "":
+		return errors.New("table is required for the builder query format")
+	case metric.Spec.QueryFormat != v1beta1.MetricQueryFormatBuilder && metric.Spec.SQL == "":
+		return errors.New("sql is required for the sql query format")
+	}
+
+	return validateMetricCap(metric.Spec.Cap)
+}
+
+// validateMetricCap validates the cap value of a metric is a number
+func validateMetricCap(cap *v1beta1.MetricCap) error {
+	if cap == nil {
+		return nil
+	}
+
+	if _, err := strconv.ParseFloat(cap.Value, 64); err != nil {
+		return fmt.Errorf("cap value %q is not a number", cap.Value)
+	}
+
+	return nil
+}
+
+// validateFactMetricDenominator validates only ratio metrics have a denominator
+func validateFactMetricDenominator(factMetric v1beta1.GrowthbookFactMetric) error {
+	switch {
+	case factMetric.Spec.MetricType == v1beta1.FactMetricTypeRatio && factMetric.Spec.Denominator == nil:
+		return errors.New("ratio metrics require a denominator")
+	case factMetric.Spec.MetricType != v1beta1.FactMetricTypeRatio && factMetric.Spec.Denominator != nil:
+		return fmt.Errorf("%s metrics can not have a denominator", factMetric.Spec.MetricType)
+	}
+
+	return nil
+}
+
+// validateTeamRoles validates the roles a team grants
+func validateTeamRoles(team v1beta1.GrowthbookTeam, refs *organizationReferences) error {
+	if err := refs.role(team.Spec.Role); err != nil {
+		return err
+	}
+
+	for _, projectRole := range team.Spec.ProjectRoles {
+		if err := refs.role(projectRole.Role); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateTeamEnvironments validates the environments the roles of a team are limited to
+func validateTeamEnvironments(team v1beta1.GrowthbookTeam, refs *organizationReferences) error {
+	for _, env := range team.Spec.Environments {
+		if err := refs.environment(env); err != nil {
+			return err
+		}
+	}
+
+	for _, projectRole := range team.Spec.ProjectRoles {
+		for _, env := range projectRole.Environments {
+			if err := refs.environment(env); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileTags(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	refs.tags = nil
+	if org.Spec.RequireDeclaredTags {
+		refs.tags = []string{}
+	}
+
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookTag, *v1beta1.GrowthbookTag]{
+		conflict: "tag",
+		list: func() ([]v1beta1.GrowthbookTag, error) {
+			var tags v1beta1.GrowthbookTagList
+			err := r.listOrganizationResources(ctx, instance, org, &tags)
+			return tags.Items, err
+		},
+		id: (*v1beta1.GrowthbookTag).GetTag,
+		deletionPolicy: func(tag *v1beta1.GrowthbookTag) v1beta1.DeletionPolicy {
+			return tag.Spec.DeletionPolicy
+		},
+		claim: func(tag *v1beta1.GrowthbookTag) {
+			if refs.tags != nil {
+				refs.tags = append(refs.tags, tag.GetTag())
+			}
+		},
+		convert: func(tag *v1beta1.GrowthbookTag) (document, error) {
+			t := growthbook.Tag{
+				Organization: org.GetID(),
+			}
+
+			t.FromV1beta1(*tag)
+
+			return document{
+				resource:     tag,
+				organization: t.Organization,
+				id:           t.ID,
+				body:         t,
+				diff:         func() ([]string, error) { return growthbook.DiffTag(ctx, t, db) },
+				update:       func() error { return growthbook.UpdateTag(ctx, t, db) },
+				meta:         func(ctx context.Context) (growthbook.DocumentMeta, error) { return growthbook.GetTagMeta(ctx, t, db) },
+			}, nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileAttributes(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	instance, err := reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookAttribute, *v1beta1.GrowthbookAttribute]{
+		conflict: "attribute",
+		list: func() ([]v1beta1.GrowthbookAttribute, error) {
+			var attributes v1beta1.GrowthbookAttributeList
+			err := r.listOrganizationResources(ctx, instance, org, &attributes)
+			return attributes.Items, err
+		},
+		id: (*v1beta1.GrowthbookAttribute).GetProperty,
+		deletionPolicy: func(attribute *v1beta1.GrowthbookAttribute) v1beta1.DeletionPolicy {
+			return attribute.Spec.DeletionPolicy
+		},
+		// Attributes are part of the organization document, their removal is covered by the deletion policy of the organization
+		orphan: func(attribute *v1beta1.GrowthbookAttribute) bool {
+			return !org.DeletionTimestamp.IsZero()
+		},
+		convert: func(attribute *v1beta1.GrowthbookAttribute) (document, error) {
+			a := growthbook.Attribute{
+				Organization: org.GetID(),
+			}
+
+			a.FromV1beta1(*attribute)
+
+			doc := document{
+				resource:     attribute,
+				organization: a.Organization,
+				id:           a.Property,
+				diff:         func() ([]string, error) { return growthbook.DiffAttribute(ctx, a, db) },
+				update:       func() error { return growthbook.UpdateAttribute(ctx, a, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetAttributeMeta(ctx, a, db)
+				},
+			}
+
+			for _, name := range attribute.Spec.Projects {
+				project, err := refs.project(name)
+				if err != nil {
+					return doc, err
+				}
+
+				a.Projects = append(a.Projects, project)
+			}
+
+			doc.body = a
+			return doc, nil
+		},
+	})
+	if err != nil {
+		return instance, err
+	}
+
+	// Features are validated against the live schema which includes attributes declared outside of kubernetes
+	schema, err := growthbook.GetAttributeSchema(ctx, org.GetID(), db)
+	if err != nil {
+		return instance, err
+	}
+
+	refs.attributes = []string{}
+	for _, attribute := range schema {
+		refs.attributes = append(refs.attributes, attribute.Property)
+	}
+
+	return instance, nil
+}
+
+// featureAttributes returns the attributes used by the conditions and hash attributes of a feature.
+// Conditions which can not be parsed are skipped.
+func featureAttributes(feature v1beta1.GrowthbookFeature) []string {
+	var attributes []string
+	for _, env := range feature.Spec.Environments {
+		for _, rule := range env.Rules {
+			if conditionAttributes, err := growthbook.ConditionAttributes(rule.Condition); err == nil {
+				attributes = append(attributes, conditionAttributes...)
+			}
+
+			if rule.HashAttribute != "" {
+				attributes = append(attributes, rule.HashAttribute)
+			}
+
+			if rule.FallbackAttribute != nil && *rule.FallbackAttribute != "" {
+				attributes = append(attributes, *rule.FallbackAttribute)
+			}
+		}
+	}
+
+	return attributes
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileSavedGroups(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	var features v1beta1.GrowthbookFeatureList
+	if err := r.listOrganizationResources(ctx, instance, org, &features); err != nil {
+		return instance, err
+	}
+
+	var experiments v1beta1.GrowthbookExperimentList
+	if err := r.listOrganizationResources(ctx, instance, org, &experiments); err != nil {
+		return instance, err
+	}
+
+	referencedBy := savedGroupReferences(features.Items, experiments.Items)
+	refs.savedGroups = make(map[string]string)
+
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookSavedGroup, *v1beta1.GrowthbookSavedGroup]{
+		conflict: "saved group id",
+		list: func() ([]v1beta1.GrowthbookSavedGroup, error) {
+			var savedGroups v1beta1.GrowthbookSavedGroupList
+			err := r.listOrganizationResources(ctx, instance, org, &savedGroups)
+			return savedGroups.Items, err
+		},
+		id: (*v1beta1.GrowthbookSavedGroup).GetID,
+		deletionPolicy: func(savedGroup *v1beta1.GrowthbookSavedGroup) v1beta1.DeletionPolicy {
+			return savedGroup.Spec.DeletionPolicy
+		},
+		claim: func(savedGroup *v1beta1.GrowthbookSavedGroup) {
+			refs.savedGroups[savedGroup.Name] = savedGroup.GetID()
+		},
+		// Saved groups which are still referenced by features are kept until the references have been removed
+		retain: func(savedGroup *v1beta1.GrowthbookSavedGroup) bool {
+			return len(referencedBy[savedGroup.Name]) > 0
+		},
+		convert: func(savedGroup *v1beta1.GrowthbookSavedGroup) (document, error) {
+			s := growthbook.SavedGroup{
+				Organization: org.GetID(),
+			}
+
+			s.FromV1beta1(*savedGroup)
+
+			doc := document{
+				resource:     savedGroup,
+				organization: s.Organization,
+				id:           s.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffSavedGroup(ctx, s, db) },
+				update:       func() error { return growthbook.UpdateSavedGroup(ctx, s, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetSavedGroupMeta(ctx, s, db)
+				},
+			}
+
+			for _, name := range savedGroup.Spec.Projects {
+				project, err := refs.project(name)
+				if err != nil {
+					return doc, err
+				}
+
+				s.Projects = append(s.Projects, project)
+			}
+
+			values, err := r.getSavedGroupValues(ctx, *savedGroup)
+			if err != nil {
+				return doc, err
+			}
+
+			s.Values = append(s.Values, values...)
+			doc.body = s
+			return doc, nil
+		},
+		applied: func(savedGroup *v1beta1.GrowthbookSavedGroup, doc document) error {
+			if !savedGroup.DeletionTimestamp.IsZero() {
+				state.record(documentResult{
+					document: doc,
+					err:      fmt.Errorf("deletion blocked, saved group is still referenced by %s", strings.Join(referencedBy[savedGroup.Name], ", ")),
+				})
+			}
+
+			return nil
+		},
+	})
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileExperiments(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	refs.experiments = make(map[string]string)
+	refs.variations = make(map[string]map[string]string)
+
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookExperiment, *v1beta1.GrowthbookExperiment]{
+		conflict: "experiment id",
+		list: func() ([]v1beta1.GrowthbookExperiment, error) {
+			var experiments v1beta1.GrowthbookExperimentList
+			if err := r.listOrganizationResources(ctx, instance, org, &experiments); err != nil {
+				return nil, err
+			}
+
+			var features v1beta1.GrowthbookFeatureList
+			if err := r.listOrganizationResources(ctx, instance, org, &features); err != nil {
+				return nil, err
+			}
+
+			refs.namespaceAllocations = namespaceAllocations(features.Items, experiments.Items)
+			return experiments.Items, nil
+		},
+		id: (*v1beta1.GrowthbookExperiment).GetID,
+		deletionPolicy: func(experiment *v1beta1.GrowthbookExperiment) v1beta1.DeletionPolicy {
+			return experiment.Spec.DeletionPolicy
+		},
+		claim: func(experiment *v1beta1.GrowthbookExperiment) {
+			refs.experiments[experiment.Name] = experiment.GetID()
+
+			e := growthbook.Experiment{}
+			e.FromV1beta1(*experiment)
+			refs.variations[experiment.Name] = variationIDs(e)
+		},
+		convert: func(experiment *v1beta1.GrowthbookExperiment) (document, error) {
+			e := growthbook.Experiment{
+				Organization: org.GetID(),
+			}
+
+			resolved, resolveErr := resolveExperimentReferences(ctx, *experiment, e.Organization, refs, db)
+			e.FromV1beta1(resolved)
+
+			doc := document{
+				resource:     experiment,
+				organization: e.Organization,
+				id:           e.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffExperiment(ctx, e, db) },
+				update:       func() error { return growthbook.UpdateExperiment(ctx, e, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetExperimentMeta(ctx, e, db)
+				},
+			}
+
+			if resolveErr != nil {
+				return doc, resolveErr
+			}
+
+			project, err := refs.project(experiment.Spec.Project)
+			if err != nil {
+				return doc, err
+			}
+
+			for _, phase := range experiment.Spec.Phases {
+				if err := refs.namespace(phase.Namespace); err != nil {
+					return doc, err
+				}
+			}
+
+			if err := refs.namespaceConflict(fmt.Sprintf("GrowthbookExperiment/%s", experiment.Name)); err != nil {
+				return doc, err
+			}
+
+			e.Project = project
+			doc.body = e
+			return doc, nil
+		},
+	})
+}
+
+// savedGroupReferences returns the resources referencing a saved group by the saved group name
+func savedGroupReferences(features []v1beta1.GrowthbookFeature, experiments []v1beta1.GrowthbookExperiment) map[string][]string {
+	referencedBy := make(map[string][]string)
+	add := func(savedGroups []v1beta1.SavedGroupTargeting, resource string) {
+		for _, savedGroup := range savedGroups {
+			for _, name := range savedGroup.IDs {
+				if !slices.Contains(referencedBy[name], resource) {
+					referencedBy[name] = append(referencedBy[name], resource)
+				}
+			}
+		}
+	}
+
+	for _, feature := range features {
+		if !feature.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, env := range feature.Spec.Environments {
+			for _, rule := range env.Rules {
+				add(rule.SavedGroups, fmt.Sprintf("GrowthbookFeature/%s", feature.Name))
+			}
+		}
+	}
+
+	for _, experiment := range experiments {
+		if !experiment.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, phase := range experiment.Spec.Phases {
+			add(phase.SavedGroups, fmt.Sprintf("GrowthbookExperiment/%s", experiment.Name))
+		}
+	}
+
+	return referencedBy
+}
+
+// namespaceAllocations returns the namespace ranges used by enabled experiment rules of features and running GrowthbookExperiments
+func namespaceAllocations(features []v1beta1.GrowthbookFeature, experiments []v1beta1.GrowthbookExperiment) []namespaceAllocation {
+	var allocations []namespaceAllocation
+	add := func(namespace *v1beta1.NamespaceValue, environment, resource string) {
+		if namespace == nil || !namespace.Enabled {
+			return
+		}
+
+		// Invalid ranges are reported by the resource itself
+		start, end, err := parseNamespaceRange(*namespace)
+		if err != nil {
+			return
+		}
+
+		allocations = append(allocations, namespaceAllocation{
+			namespace:   namespace.Name,
+			start:       start,
+			end:         end,
+			environment: environment,
+			resource:    resource,
+		})
+	}
+
+	for _, feature := range features {
+		if !feature.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, env := range feature.Spec.Environments {
+			if !env.Enabled {
+				continue
+			}
+
+			for _, rule := range env.Rules {
+				if rule.Type == v1beta1.FeatureRuleTypeExperiment && rule.Enabled {
+					add(rule.Namespace, env.Name, fmt.Sprintf("GrowthbookFeature/%s", feature.Name))
+				}
+			}
+		}
+	}
+
+	for _, experiment := range experiments {
+		if !experiment.DeletionTimestamp.IsZero() || experiment.Spec.Status != v1beta1.ExperimentStatusRunning || len(experiment.Spec.Phases) == 0 {
+			continue
+		}
+
+		// Only the current phase of an experiment is running
+		add(experiment.Spec.Phases[len(experiment.Spec.Phases)-1].Namespace, "", fmt.Sprintf("GrowthbookExperiment/%s", experiment.Name))
+	}
+
+	return allocations
+}
+
+// parseNamespaceRange returns the start and end of a namespace range which must be within [0,1]
+func parseNamespaceRange(namespace v1beta1.NamespaceValue) (float64, float64, error) {
+	if len(namespace.Range) != 2 {
+		return 0, 0, fmt.Errorf("range of namespace %s must consist of a start and an end", namespace.Name)
+	}
+
+	start, err := strconv.ParseFloat(namespace.Range[0], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range start of namespace %s: %w", namespace.Name, err)
+	}
+
+	end, err := strconv.ParseFloat(namespace.Range[1], 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid range end of namespace %s: %w", namespace.Name, err)
+	}
+
+	if start < 0 || end > 1 {
+		return 0, 0, fmt.Errorf("range [%s, %s] of namespace %s is not within [0,1]", namespace.Range[0], namespace.Range[1], namespace.Name)
+	}
+
+	if start >= end {
+		return 0, 0, fmt.Errorf("range start of namespace %s must be lower than its end", namespace.Name)
+	}
+
+	return start, end, nil
+}
+
+// resolveFeatureReferences returns a copy of the feature with saved group and experiment names replaced by their growthbook ids
+func resolveFeatureReferences(ctx context.Context, feature v1beta1.GrowthbookFeature, organization string, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookFeature, error) {
+	resolved := *feature.DeepCopy()
+	for _, env := range resolved.Spec.Environments {
+		for i, rule := range env.Rules {
+			for _, savedGroup := range rule.SavedGroups {
+				for k, name := range savedGroup.IDs {
+					id, err := refs.savedGroup(ctx, organization, name, db)
+					if err != nil {
+						return resolved, err
+					}
+
+					savedGroup.IDs[k] = id
+				}
+			}
+
+			if rule.ExperimentID == "" {
+				continue
+			}
+
+			experimentID, variations, err := refs.experiment(ctx, organization, rule.ExperimentID, db)
+			if err != nil {
+				return resolved, err
+			}
+
+			for j, variation := range rule.Variations {
+				id, ok := variations[variation.VariationId]
+				if !ok {
+					return resolved, fmt.Errorf("referenced variation %s not found in experiment %s", variation.VariationId, rule.ExperimentID)
+				}
+
+				env.Rules[i].Variations[j].VariationId = id
+			}
+
+			env.Rules[i].ExperimentID = experimentID
+		}
+	}
+
+	return resolved, nil
+}
+
+// resolveExperimentReferences returns a copy of the experiment with saved group names replaced by their growthbook ids
+func resolveExperimentReferences(ctx context.Context, experiment v1beta1.GrowthbookExperiment, organization string, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookExperiment, error) {
+	resolved := *experiment.DeepCopy()
+	for _, phase := range resolved.Spec.Phases {
+		for _, savedGroup := range phase.SavedGroups {
+			for i, name := range savedGroup.IDs {
+				id, err := refs.savedGroup(ctx, organization, name, db)
+				if err != nil {
+					return resolved, err
+				}
+
+				savedGroup.IDs[i] = id
+			}
+		}
+	}
+
+	return resolved, nil
+}
+
+func (r *GrowthbookInstanceReconciler) reconcileFeatures(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookFeature, *v1beta1.GrowthbookFeature]{
+		conflict: "feature id",
+		list: func() ([]v1beta1.GrowthbookFeature, error) {
+			var features v1beta1.GrowthbookFeatureList
+			err := r.listOrganizationResources(ctx, instance, org, &features)
+			return features.Items, err
+		},
+		id: (*v1beta1.GrowthbookFeature).GetID,
+		deletionPolicy: func(feature *v1beta1.GrowthbookFeature) v1beta1.DeletionPolicy {
+			return feature.Spec.DeletionPolicy
+		},
+		convert: func(feature *v1beta1.GrowthbookFeature) (document, error) {
+			f := growthbook.Feature{
+				Owner:        owner,
+				Organization: org.GetID(),
+			}
+
+			resolved, resolveErr := resolveFeatureReferences(ctx, *feature, f.Organization, refs, db)
+			f.FromV1beta1(resolved)
+
+			doc := document{
+				resource:     feature,
+				organization: f.Organization,
+				id:           f.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffFeature(ctx, f, db) },
+				update:       func() error { return growthbook.UpdateFeature(ctx, f, db, &state.changes) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetFeatureMeta(ctx, f, db)
+				},
+			}
+
+			if resolveErr != nil {
+				return doc, resolveErr
+			}
+
+			project, err := refs.project(feature.Spec.Project)
+			if err != nil {
+				return doc, err
+			}
+
+			for _, env := range feature.Spec.Environments {
+				if err := refs.environment(env.Name); err != nil {
+					return doc, err
+				}
+			}
+
+			for _, tag := range feature.Spec.Tags {
+				if err := refs.tag(tag); err != nil {
+					return doc, err
+				}
+			}
+
+			for _, env := range feature.Spec.Environments {
+				for _, rule := range env.Rules {
+					if err := refs.namespace(rule.Namespace); err != nil {
+						return doc, err
+					}
+				}
+			}
+
+			if err := refs.namespaceConflict(fmt.Sprintf("GrowthbookFeature/%s", feature.Name)); err != nil {
+				return doc, err
 			}
-		}
-	}
 
-	return instance, nil
+			f.Project = project
+			doc.body = f
+			doc.unknownAttributes = refs.unknownAttributes(featureAttributes(*feature))
+			return doc, nil
+		},
+	})
 }
 
 func (r *GrowthbookInstanceReconciler) addFinalizer(ctx context.Context, finalizerName string, obj metav1.PartialObjectMetadata) error {
@@ -521,7 +2789,7 @@ func (r *GrowthbookInstanceReconciler) removeFinalizer(ctx context.Context, fina
 	return nil
 }
 
-func (r *GrowthbookInstanceReconciler) reconcileUsers(ctx context.Context, instance v1beta1.GrowthbookInstance, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+func (r *GrowthbookInstanceReconciler) reconcileUsers(ctx context.Context, instance v1beta1.GrowthbookInstance, state *reconcileState, db storage.Database) (v1beta1.GrowthbookInstance, error) {
 	var users v1beta1.GrowthbookUserList
 	finalizerName := fmt.Sprintf("%s/%s.%s", v1beta1.Finalizer, instance.Name, instance.Namespace)
 
@@ -552,8 +2820,17 @@ func (r *GrowthbookInstanceReconciler) reconcileUsers(ctx context.Context, insta
 		u.FromV1beta1(user)
 
 		if user.DeletionTimestamp.IsZero() && instance.DeletionTimestamp.IsZero() {
+			doc := document{
+				resource: &user,
+				id:       u.ID,
+				diff:     func() ([]string, error) { return growthbook.DiffUser(ctx, u, db) },
+				update:   func() error { return growthbook.UpdateUser(ctx, u, db) },
+				meta:     func(ctx context.Context) (growthbook.DocumentMeta, error) { return growthbook.GetUserMeta(ctx, u, db) },
+			}
+
 			username, password, err := r.getOptionalUsernamePassword(ctx, instance, user.Spec.Secret)
 			if err != nil {
+				state.record(documentResult{document: doc, err: err})
 				return instance, err
 			}
 
@@ -562,14 +2839,17 @@ func (r *GrowthbookInstanceReconciler) reconcileUsers(ctx context.Context, insta
 			}
 
 			if err := u.SetPassword(ctx, db, password); err != nil {
+				state.record(documentResult{document: doc, err: err})
 				return instance, err
 			}
 
-			if err := growthbook.UpdateUser(ctx, u, db); err != nil {
+			doc.body = u
+			instance, err = r.applyDocument(ctx, instance, state, doc)
+			if err != nil {
 				return instance, err
 			}
 		} else {
-			if instance.Spec.Prune {
+			if instance.GetDeletionPolicy(user.Spec.DeletionPolicy) == v1beta1.DeletionPolicyDelete {
 				if err := growthbook.DeleteUser(ctx, u, db); err != nil {
 					return instance, err
 				}
@@ -584,51 +2864,50 @@ func (r *GrowthbookInstanceReconciler) reconcileUsers(ctx context.Context, insta
 	return instance, nil
 }
 
-func (r *GrowthbookInstanceReconciler) reconcileClients(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, db storage.Database) (v1beta1.GrowthbookInstance, error) {
-	var clients v1beta1.GrowthbookClientList
-	finalizerName := fmt.Sprintf("%s/%s.%s", v1beta1.Finalizer, instance.Name, instance.Namespace)
-
-	selector, err := metav1.LabelSelectorAsSelector(org.Spec.ResourceSelector)
-	if err != nil {
-		return instance, err
-	}
-
-	instanceSelector, err := metav1.LabelSelectorAsSelector(instance.Spec.ResourceSelector)
-	if err != nil {
-		return instance, err
-	}
-
-	req, _ := instanceSelector.Requirements()
-	selector.Add(req...)
-
-	err = r.Client.List(ctx, &clients, client.InNamespace(instance.Namespace), client.MatchingLabelsSelector{Selector: selector})
-	if err != nil {
-		return instance, err
-	}
+func (r *GrowthbookInstanceReconciler) reconcileClients(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookClient, *v1beta1.GrowthbookClient]{
+		conflict: "client id",
+		list: func() ([]v1beta1.GrowthbookClient, error) {
+			var clients v1beta1.GrowthbookClientList
+			err := r.listOrganizationResources(ctx, instance, org, &clients)
+			return clients.Items, err
+		},
+		id: (*v1beta1.GrowthbookClient).GetID,
+		deletionPolicy: func(client *v1beta1.GrowthbookClient) v1beta1.DeletionPolicy {
+			return client.Spec.DeletionPolicy
+		},
+		convert: func(client *v1beta1.GrowthbookClient) (document, error) {
+			s := growthbook.SDKConnection{
+				Organization: org.GetID(),
+			}
 
-	if instance.DeletionTimestamp.IsZero() {
-		for _, client := range clients.Items {
-			if err := r.addFinalizer(ctx, finalizerName, metav1.PartialObjectMetadata{TypeMeta: client.TypeMeta, ObjectMeta: client.ObjectMeta}); err != nil {
-				return instance, err
+			s.FromV1beta1(*client)
+
+			doc := document{
+				resource:     client,
+				organization: s.Organization,
+				id:           s.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffSDKConnection(ctx, s, db) },
+				update:       func() error { return growthbook.UpdateSDKConnection(ctx, s, db, &state.changes) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetSDKConnectionMeta(ctx, s, db)
+				},
 			}
 
-			if client.DeletionTimestamp.IsZero() {
-				instance = updateResourceCatalog(instance, &client)
+			project, err := refs.project(client.Spec.Project)
+			if err != nil {
+				return doc, err
 			}
-		}
-	}
 
-	for _, client := range clients.Items {
-		s := growthbook.SDKConnection{
-			Organization: org.GetID(),
-		}
+			s.Project = project
 
-		s.FromV1beta1(client)
+			if err := refs.environment(client.Spec.Environment); err != nil {
+				return doc, err
+			}
 
-		if client.DeletionTimestamp.IsZero() && instance.DeletionTimestamp.IsZero() {
-			token, err := r.getClientToken(ctx, client)
+			token, err := r.getClientToken(ctx, *client)
 			if err != nil {
-				return instance, err
+				return doc, err
 			}
 
 			if token[:4] != "sdk-" {
@@ -636,25 +2915,150 @@ func (r *GrowthbookInstanceReconciler) reconcileClients(ctx context.Context, ins
 			}
 
 			s.Key = token
+			doc.body = s
+			return doc, nil
+		},
+	})
+}
 
-			if err := growthbook.UpdateSDKConnection(ctx, s, db); err != nil {
-				return instance, err
+func (r *GrowthbookInstanceReconciler) reconcileAPIKeys(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization, state *reconcileState, refs *organizationReferences, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	return reconcileDocuments(ctx, r, instance, org, state, db, documentKind[v1beta1.GrowthbookAPIKey, *v1beta1.GrowthbookAPIKey]{
+		conflict: "api key id",
+		list: func() ([]v1beta1.GrowthbookAPIKey, error) {
+			var apiKeys v1beta1.GrowthbookAPIKeyList
+			err := r.listOrganizationResources(ctx, instance, org, &apiKeys)
+			return apiKeys.Items, err
+		},
+		id: (*v1beta1.GrowthbookAPIKey).GetID,
+		deletionPolicy: func(apiKey *v1beta1.GrowthbookAPIKey) v1beta1.DeletionPolicy {
+			return apiKey.Spec.DeletionPolicy
+		},
+		convert: func(apiKey *v1beta1.GrowthbookAPIKey) (document, error) {
+			k := growthbook.APIKey{
+				Organization: org.GetID(),
 			}
-		} else {
-			if instance.Spec.Prune {
-				if err := growthbook.DeleteSDKConnection(ctx, s, db); err != nil {
-					return instance, err
+
+			k.FromV1beta1(*apiKey)
+
+			doc := document{
+				resource:     apiKey,
+				organization: k.Organization,
+				id:           k.ID,
+				diff:         func() ([]string, error) { return growthbook.DiffAPIKey(ctx, k, db) },
+				update:       func() error { return growthbook.UpdateAPIKey(ctx, k, db) },
+				meta: func(ctx context.Context) (growthbook.DocumentMeta, error) {
+					return growthbook.GetAPIKeyMeta(ctx, k, db)
+				},
+			}
+
+			if apiKey.Spec.TokenSecret == nil {
+				return doc, errors.New("no secret reference provided")
+			}
+
+			project, err := refs.project(apiKey.Spec.Project)
+			if err != nil {
+				return doc, err
+			}
+
+			k.Project = project
+
+			if apiKey.Spec.Environment != "" {
+				if err := refs.environment(apiKey.Spec.Environment); err != nil {
+					return doc, err
 				}
 			}
 
-			if err := r.removeFinalizer(ctx, finalizerName, metav1.PartialObjectMetadata{TypeMeta: client.TypeMeta, ObjectMeta: client.ObjectMeta}); err != nil {
-				return instance, err
+			if apiKey.Spec.User != "" {
+				var user v1beta1.GrowthbookUser
+				if err := r.Client.Get(ctx, types.NamespacedName{Namespace: apiKey.Namespace, Name: apiKey.Spec.User}, &user); err != nil {
+					return doc, fmt.Errorf("referenced user %s not found: %w", apiKey.Spec.User, err)
+				}
+
+				k.UserID = user.GetID()
+			}
+
+			// A new key is generated whenever the value of the rotate annotation changes
+			if rotation, ok := state.rotations[client.ObjectKeyFromObject(apiKey)]; ok {
+				k.Key = rotation.key
+				doc.rotation = rotation.rotation
 			}
+
+			doc.body = k
+			return doc, nil
+		},
+		// The key is read back once committed, the secret must never hold a key which has been rolled back
+		applied: func(apiKey *v1beta1.GrowthbookAPIKey, doc document) error {
+			state.tokens = append(state.tokens, apiKeyToken{apiKey: *apiKey, doc: doc})
+			return nil
+		},
+	})
+}
+
+// apiKeyRotations generates a new key for all api keys of an organization whose rotate annotation has changed
+func (r *GrowthbookInstanceReconciler) apiKeyRotations(ctx context.Context, instance v1beta1.GrowthbookInstance, org v1beta1.GrowthbookOrganization) (map[types.NamespacedName]apiKeyRotation, error) {
+	var apiKeys v1beta1.GrowthbookAPIKeyList
+	if err := r.listOrganizationResources(ctx, instance, org, &apiKeys); err != nil {
+		return nil, err
+	}
+
+	rotations := make(map[types.NamespacedName]apiKeyRotation)
+	for _, apiKey := range apiKeys.Items {
+		rotation := apiKey.Annotations[v1beta1.RotateAnnotation]
+		if rotation == "" || rotation == apiKey.Status.LastRotation {
+			continue
+		}
+
+		k := growthbook.APIKey{}
+		k.FromV1beta1(apiKey)
+
+		key, err := growthbook.GenerateAPIKey(k)
+		if err != nil {
+			return nil, err
 		}
 
+		rotations[client.ObjectKeyFromObject(&apiKey)] = apiKeyRotation{rotation: rotation, key: key}
 	}
 
-	return instance, nil
+	return rotations, nil
+}
+
+// writeAPIKeySecret writes the stored key of an api key to its token secret.
+// A secret created by the controller is owned by the api key and garbage collected along with it,
+// existing secrets are only updated.
+func (r *GrowthbookInstanceReconciler) writeAPIKeySecret(ctx context.Context, apiKey v1beta1.GrowthbookAPIKey, k growthbook.APIKey, db storage.Database) error {
+	stored, err := growthbook.GetAPIKey(ctx, k, db)
+	if err != nil {
+		return fmt.Errorf("failed to read api key %s: %w", k.ID, err)
+	}
+
+	tokenFieldName := "token"
+	if apiKey.Spec.TokenSecret.TokenField != "" {
+		tokenFieldName = apiKey.Spec.TokenSecret.TokenField
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: apiKey.Namespace,
+			Name:      apiKey.Spec.TokenSecret.Name,
+		},
+	}
+
+	_, err = controllerutil.CreateOrPatch(ctx, r.Client, secret, func() error {
+		if secret.CreationTimestamp.IsZero() {
+			if err := controllerutil.SetOwnerReference(&apiKey, secret, r.Scheme); err != nil {
+				return err
+			}
+		}
+
+		if secret.Data == nil {
+			secret.Data = make(map[string][]byte)
+		}
+
+		secret.Data[tokenFieldName] = []byte(stored.Key)
+		return nil
+	})
+
+	return err
 }
 
 func (r *GrowthbookInstanceReconciler) getSecret(ctx context.Context, ref types.NamespacedName) (*corev1.Secret, error) {
@@ -668,6 +3072,42 @@ func (r *GrowthbookInstanceReconciler) getSecret(ctx context.Context, ref types.
 	return secret, nil
 }
 
+// getSavedGroupValues returns the values of a saved group from the referenced ConfigMap, one value per line
+func (r *GrowthbookInstanceReconciler) getSavedGroupValues(ctx context.Context, savedGroup v1beta1.GrowthbookSavedGroup) ([]string, error) {
+	if savedGroup.Spec.ValuesFrom == nil {
+		return nil, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Client.Get(ctx, types.NamespacedName{
+		Namespace: savedGroup.Namespace,
+		Name:      savedGroup.Spec.ValuesFrom.Name,
+	}, configMap)
+
+	if err != nil {
+		return nil, fmt.Errorf("referencing configmap was not found: %w", err)
+	}
+
+	key := "values"
+	if savedGroup.Spec.ValuesFrom.Key != "" {
+		key = savedGroup.Spec.ValuesFrom.Key
+	}
+
+	data, ok := configMap.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("defined key %s not found in configmap", key)
+	}
+
+	var values []string
+	for _, value := range strings.Split(data, "\n") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values, nil
+}
+
 func (r *GrowthbookInstanceReconciler) getClientToken(ctx context.Context, client v1beta1.GrowthbookClient) (string, error) {
 	if client.Spec.TokenSecret == nil {
 		return "", errors.New("no secret reference provided")
@@ -694,12 +3134,84 @@ func (r *GrowthbookInstanceReconciler) getClientToken(ctx context.Context, clien
 	}
 }
 
-func updateResourceCatalog(instance v1beta1.GrowthbookInstance, resource client.Object) v1beta1.GrowthbookInstance {
-	resRef := v1beta1.ResourceReference{
+// getDataSourceParams returns the connection params of a data source, the keys of the params secret are added as strings
+func (r *GrowthbookInstanceReconciler) getDataSourceParams(ctx context.Context, dataSource v1beta1.GrowthbookDataSource) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	if dataSource.Spec.Params != "" {
+		if err := json.Unmarshal([]byte(dataSource.Spec.Params), &params); err != nil || params == nil {
+			return nil, errors.New("params must be a JSON object")
+		}
+	}
+
+	if dataSource.Spec.ParamsSecret == nil {
+		return params, nil
+	}
+
+	secret, err := r.getSecret(ctx, types.NamespacedName{
+		Namespace: dataSource.Namespace,
+		Name:      dataSource.Spec.ParamsSecret.Name,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for key, val := range secret.Data {
+		params[key] = string(val)
+	}
+
+	return params, nil
+}
+
+// paramsChecksum returns a keyed hash of data source connection params
+func paramsChecksum(params map[string]interface{}, key string) (string, error) {
+	b, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(b)
+	return fmt.Sprintf("%x", mac.Sum(nil)), nil
+}
+
+// getEncryptionKey returns the key the growthbook backend uses to encrypt data source params
+func (r *GrowthbookInstanceReconciler) getEncryptionKey(ctx context.Context, instance v1beta1.GrowthbookInstance) (string, error) {
+	if instance.Spec.EncryptionKeySecret == nil {
+		return "", errors.New("no encryption key secret configured on the instance")
+	}
+
+	secret, err := r.getSecret(ctx, types.NamespacedName{
+		Namespace: instance.Namespace,
+		Name:      instance.Spec.EncryptionKeySecret.Name,
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	keyFieldName := "ENCRYPTION_KEY"
+	if instance.Spec.EncryptionKeySecret.KeyField != "" {
+		keyFieldName = instance.Spec.EncryptionKeySecret.KeyField
+	}
+
+	if val, ok := secret.Data[keyFieldName]; !ok {
+		return "", errors.New("defined encryption key field not found in secret")
+	} else {
+		return string(val), nil
+	}
+}
+
+func newResourceReference(resource client.Object) v1beta1.ResourceReference {
+	return v1beta1.ResourceReference{
 		Kind:       resource.GetObjectKind().GroupVersionKind().Kind,
 		Name:       resource.GetName(),
 		APIVersion: fmt.Sprintf("%s/%s", resource.GetObjectKind().GroupVersionKind().Group, resource.GetObjectKind().GroupVersionKind().Version),
 	}
+}
+
+func updateResourceCatalog(instance v1beta1.GrowthbookInstance, resource client.Object) v1beta1.GrowthbookInstance {
+	resRef := newResourceReference(resource)
 
 	if !slices.Contains(instance.Status.SubResourceCatalog, resRef) {
 		instance.Status.SubResourceCatalog = append(instance.Status.SubResourceCatalog, resRef)
@@ -708,6 +3220,214 @@ func updateResourceCatalog(instance v1beta1.GrowthbookInstance, resource client.
 	return instance
 }
 
+// applyDocument applies a growthbook document rendered from a resource.
+// Changes made outside of kubernetes are handled according to the drift policy of the instance.
+func (r *GrowthbookInstanceReconciler) applyDocument(ctx context.Context, instance v1beta1.GrowthbookInstance, state *reconcileState, doc document) (v1beta1.GrowthbookInstance, error) {
+	result := documentResult{document: doc}
+	entry, err := newInventoryEntry(doc.resource, doc.organization, doc.id, doc.body)
+	if err != nil {
+		result.err = err
+		state.record(result)
+		return instance, err
+	}
+
+	// Differences are only considered as drift if the resource did not change since it was applied the last time
+	var drifted bool
+	if slices.Contains(state.previousInventory, entry) {
+		fields, err := doc.diff()
+		if err != nil {
+			result.err = err
+			state.record(result)
+			return instance, err
+		}
+
+		drifted = len(fields) > 0
+		if drifted && instance.Spec.DriftPolicy != v1beta1.DriftPolicyIgnore {
+			result.drifted = fields
+			instance.Status.DriftedResources = append(instance.Status.DriftedResources, v1beta1.DriftedResource{
+				ResourceReference: entry.ResourceReference,
+				Organization:      doc.organization,
+				Fields:            fields,
+			})
+
+			state.eventf(&instance, "Warning", "DriftDetected", "%s %s has been changed outside of kubernetes: %s", entry.Kind, entry.Name, strings.Join(fields, ", "))
+			state.eventf(doc.resource, "Warning", "DriftDetected", "document %s has been changed outside of kubernetes: %s", doc.id, strings.Join(fields, ", "))
+		}
+	}
+
+	if !drifted || instance.Spec.DriftPolicy == v1beta1.DriftPolicyRevert || instance.Spec.DriftPolicy == "" {
+		if err := doc.update(); err != nil {
+			result.err = err
+			state.record(result)
+			return instance, err
+		}
+
+		result.applied = true
+		result.drifted = nil
+	}
+
+	state.record(result)
+	return addInventoryEntry(instance, entry), nil
+}
+
+// newInventoryEntry returns an inventory entry for the given document.
+// The checksum covers the resource generation as well, settings which do not end up in the document
+// (like the rule merge strategy of a feature) change how it is merged and must not be reported as drift.
+func newInventoryEntry(resource client.Object, organization, id string, document interface{}) (v1beta1.InventoryEntry, error) {
+	b, err := growthbook.MarshalCanonical(document)
+	if err != nil {
+		return v1beta1.InventoryEntry{}, err
+	}
+
+	b = strconv.AppendInt(b, resource.GetGeneration(), 10)
+
+	return v1beta1.InventoryEntry{
+		ResourceReference: newResourceReference(resource),
+		ID:                id,
+		Organization:      organization,
+		Checksum:          fmt.Sprintf("%x", sha256.Sum256(b)),
+	}, nil
+}
+
+func addInventoryEntry(instance v1beta1.GrowthbookInstance, entry v1beta1.InventoryEntry) v1beta1.GrowthbookInstance {
+	if !slices.ContainsFunc(instance.Status.Inventory, func(e v1beta1.InventoryEntry) bool {
+		return sameDocument(e, entry)
+	}) {
+		instance.Status.Inventory = append(instance.Status.Inventory, entry)
+	}
+
+	return instance
+}
+
+// sameDocument returns true if both inventory entries refer to the same document applied from the same resource
+func sameDocument(a, b v1beta1.InventoryEntry) bool {
+	return a.Kind == b.Kind && a.Name == b.Name && a.ID == b.ID && a.Organization == b.Organization
+}
+
+// pruneInventory handles all documents from the previous inventory which are not part of the current one anymore.
+// The documents are removed from growthbook if pruning is enabled and the finalizer is released from resources which are not selected anymore.
+func (r *GrowthbookInstanceReconciler) pruneInventory(ctx context.Context, instance v1beta1.GrowthbookInstance, state *reconcileState, db storage.Database) (v1beta1.GrowthbookInstance, error) {
+	finalizerName := fmt.Sprintf("%s/%s.%s", v1beta1.Finalizer, instance.Name, instance.Namespace)
+	previous := state.previousInventory
+	current := instance.Status.Inventory
+
+	for i, entry := range previous {
+		if slices.ContainsFunc(current, func(e v1beta1.InventoryEntry) bool {
+			return sameDocument(e, entry)
+		}) {
+			continue
+		}
+
+		// The document might now be owned by another resource
+		documentInUse := slices.ContainsFunc(current, func(e v1beta1.InventoryEntry) bool {
+			return e.Kind == entry.Kind && e.ID == entry.ID && e.Organization == entry.Organization
+		})
+
+		resourceInUse := slices.ContainsFunc(current, func(e v1beta1.InventoryEntry) bool {
+			return e.Kind == entry.Kind && e.Name == entry.Name
+		})
+
+		err := r.pruneInventoryEntry(ctx, instance, entry, finalizerName, !documentInUse, !resourceInUse, db, &state.changes)
+		if err != nil {
+			// Entries which have not been pruned are kept for the next reconciliation
+			for _, entry := range previous[i:] {
+				instance = addInventoryEntry(instance, entry)
+			}
+
+			return instance, err
+		}
+	}
+
+	return instance, nil
+}
+
+func (r *GrowthbookInstanceReconciler) pruneInventoryEntry(ctx context.Context, instance v1beta1.GrowthbookInstance, entry v1beta1.InventoryEntry, finalizerName string, pruneDocument, releaseResource bool, db storage.Database, changes *growthbook.ChangeSet) error {
+	obj := &unstructured.Unstructured{}
+	obj.SetAPIVersion(entry.APIVersion)
+	obj.SetKind(entry.Kind)
+
+	err := r.Client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: entry.Name}, obj)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	exists := err == nil
+
+	if pruneDocument {
+		// The deletion policy of the instance applies if the resource does not exist anymore
+		var policy v1beta1.DeletionPolicy
+		if exists {
+			p, _, _ := unstructured.NestedString(obj.Object, "spec", "deletionPolicy")
+			policy = v1beta1.DeletionPolicy(p)
+		}
+
+		if err := deleteDocument(ctx, entry, instance.GetDeletionPolicy(policy), db, changes); err != nil {
+			return err
+		}
+	}
+
+	if !releaseResource || !exists || !controllerutil.ContainsFinalizer(obj, finalizerName) {
+		return nil
+	}
+
+	meta := metav1.PartialObjectMetadata{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &meta); err != nil {
+		return err
+	}
+
+	return r.removeFinalizer(ctx, finalizerName, meta)
+}
+
+// deleteDocument handles an inventory entry according to the given deletion policy
+func deleteDocument(ctx context.Context, entry v1beta1.InventoryEntry, policy v1beta1.DeletionPolicy, db storage.Database, changes *growthbook.ChangeSet) error {
+	switch {
+	case entry.Kind == "GrowthbookUser" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteUser(ctx, growthbook.User{ID: entry.ID}, db)
+	case entry.Kind == "GrowthbookOrganization" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteOrganization(ctx, growthbook.Organization{ID: entry.ID}, db)
+	case entry.Kind == "GrowthbookFeature" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteFeature(ctx, growthbook.Feature{ID: entry.ID, Organization: entry.Organization}, db, changes)
+	case entry.Kind == "GrowthbookFeature" && policy == v1beta1.DeletionPolicyArchive:
+		return growthbook.ArchiveFeature(ctx, growthbook.Feature{ID: entry.ID, Organization: entry.Organization}, db, changes)
+	case entry.Kind == "GrowthbookClient" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteSDKConnection(ctx, growthbook.SDKConnection{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookProject" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteProject(ctx, growthbook.Project{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookSavedGroup" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteSavedGroup(ctx, growthbook.SavedGroup{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookExperiment" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteExperiment(ctx, growthbook.Experiment{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookExperiment" && policy == v1beta1.DeletionPolicyArchive:
+		return growthbook.ArchiveExperiment(ctx, growthbook.Experiment{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookAttribute" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteAttribute(ctx, growthbook.Attribute{Property: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookAttribute" && policy == v1beta1.DeletionPolicyArchive:
+		return growthbook.ArchiveAttribute(ctx, growthbook.Attribute{Property: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookTag" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteTag(ctx, growthbook.Tag{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookAPIKey" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteAPIKey(ctx, growthbook.APIKey{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookTeam" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteTeam(ctx, growthbook.Team{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookArchetype" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteArchetype(ctx, growthbook.Archetype{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookDataSource" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteDataSource(ctx, growthbook.DataSource{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookMetric" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteMetric(ctx, growthbook.Metric{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookFactTable" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteFactTable(ctx, growthbook.FactTable{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookFactMetric" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteFactMetric(ctx, growthbook.FactMetric{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookSegment" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteSegment(ctx, growthbook.Segment{ID: entry.ID, Organization: entry.Organization}, db)
+	case entry.Kind == "GrowthbookDimension" && policy == v1beta1.DeletionPolicyDelete:
+		return growthbook.DeleteDimension(ctx, growthbook.Dimension{ID: entry.ID, Organization: entry.Organization}, db)
+	}
+
+	return nil
+}
+
 func (r *GrowthbookInstanceReconciler) getUsernamePassword(ctx context.Context, instance v1beta1.GrowthbookInstance, secretReference *v1beta1.SecretReference) (string, string, error) {
 	if secretReference == nil {
 		return "", "", errors.New("no secret reference provided")
@@ -797,6 +3517,95 @@ func (r *GrowthbookInstanceReconciler) patchStatus(ctx context.Context, instance
 	return r.Client.Status().Patch(ctx, instance, client.MergeFrom(latest))
 }
 
+// patchDocumentStatuses updates the status of all resources whose documents have been handled by this reconciliation.
+func (r *GrowthbookInstanceReconciler) patchDocumentStatuses(ctx context.Context, instance v1beta1.GrowthbookInstance, state *reconcileState) error {
+	var err error
+	for _, result := range state.documents {
+		if patchErr := r.patchDocumentStatus(ctx, instance, result); patchErr != nil && err == nil {
+			err = patchErr
+		}
+	}
+
+	return err
+}
+
+// patchDocumentStatus updates the status of a resource from the outcome of applying its document.
+// The live document metadata is read after all transactions have been committed.
+func (r *GrowthbookInstanceReconciler) patchDocumentStatus(ctx context.Context, instance v1beta1.GrowthbookInstance, result documentResult) error {
+	resource := result.resource
+	before := resource.DeepCopyObject().(documentResource)
+
+	status := resource.GetDocumentStatus()
+	status.ObservedGeneration = resource.GetGeneration()
+	status.ID = result.id
+	status.Organization = result.organization
+	status.Instance = instance.Name
+
+	if result.err != nil {
+		reason := v1beta1.FailedReason
+		if errors.Is(result.err, errConflict) || errors.Is(result.err, growthbook.ErrConflict) {
+			reason = v1beta1.ConflictReason
+		}
+
+		v1beta1.DocumentNotReady(status, reason, result.err.Error())
+		r.Recorder.Event(resource, "Warning", reason, result.err.Error())
+	} else {
+		meta, err := result.meta(ctx)
+		if err != nil {
+			return err
+		}
+
+		var dateUpdated *metav1.Time
+		if !meta.DateUpdated.IsZero() {
+			t := metav1.NewTime(meta.DateUpdated.UTC().Truncate(time.Second))
+			dateUpdated = &t
+		}
+
+		changed := status.Revision != meta.Revision || !status.DateUpdated.Equal(dateUpdated)
+		if result.applied && (changed || status.LastAppliedTime == nil) {
+			now := metav1.Now()
+			status.LastAppliedTime = &now
+			r.Recorder.Eventf(resource, "Normal", v1beta1.SynchronizedReason, "document %s applied", result.id)
+		}
+
+		status.DateUpdated = dateUpdated
+		status.Revision = meta.Revision
+
+		if len(result.drifted) > 0 {
+			v1beta1.DocumentNotReady(status, v1beta1.DriftedReason, fmt.Sprintf("document has been changed outside of kubernetes: %s", strings.Join(result.drifted, ", ")))
+		} else {
+			v1beta1.DocumentReady(status, v1beta1.SynchronizedReason, "document successfully applied")
+		}
+	}
+
+	if feature, ok := resource.(*v1beta1.GrowthbookFeature); ok && result.err == nil {
+		if len(result.unknownAttributes) > 0 && !slices.Equal(feature.Status.UnknownAttributes, result.unknownAttributes) {
+			r.Recorder.Eventf(resource, "Warning", "UnknownAttributes", "attributes are not declared in the attribute schema: %s", strings.Join(result.unknownAttributes, ", "))
+		}
+
+		feature.Status.UnknownAttributes = result.unknownAttributes
+
+		// Rules removed from the spec are only gone from growthbook once the document has been written
+		if result.applied {
+			feature.Status.ManagedRules = growthbook.ExplicitRuleIDs(*feature)
+		}
+	}
+
+	if apiKey, ok := resource.(*v1beta1.GrowthbookAPIKey); ok && result.err == nil && result.applied && result.rotation != "" {
+		if apiKey.Status.LastRotation != result.rotation {
+			r.Recorder.Eventf(resource, "Normal", "Rotated", "api key %s rotated", result.id)
+		}
+
+		apiKey.Status.LastRotation = result.rotation
+	}
+
+	if apiequality.Semantic.DeepEqual(before, resource) {
+		return nil
+	}
+
+	return r.Client.Status().Patch(ctx, resource, client.MergeFrom(before))
+}
+
 // objectKey returns client.ObjectKey for the object.
 func objectKey(object metav1.Object) client.ObjectKey {
 	return client.ObjectKey{
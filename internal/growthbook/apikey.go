@@ -0,0 +1,145 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// APIKey is a secret key used to authenticate against the growthbook REST API
+type APIKey struct {
+	ID           string    `bson:"id"`
+	Key          string    `bson:"key"`
+	Organization string    `bson:"organization"`
+	Description  string    `bson:"description"`
+	Environment  string    `bson:"environment"`
+	Project      string    `bson:"project"`
+	Secret       bool      `bson:"secret"`
+	EncryptSDK   bool      `bson:"encryptSDK"`
+	Role         string    `bson:"role"`
+	UserID       string    `bson:"userId,omitempty"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	Revision     int       `bson:"__v"`
+}
+
+func (k *APIKey) FromV1beta1(apiKey v1beta1.GrowthbookAPIKey) *APIKey {
+	k.ID = apiKey.GetID()
+	k.Description = apiKey.Spec.Description
+	k.Environment = apiKey.Spec.Environment
+	k.Secret = true
+	k.Role = string(apiKey.Spec.Role)
+
+	if k.Role == "" {
+		k.Role = string(v1beta1.APIKeyRoleReadonly)
+	}
+
+	// Personal access tokens are bound to the permissions of the user, the user id is resolved by the caller
+	if apiKey.Spec.User != "" {
+		k.Role = "user"
+	}
+
+	return k
+}
+
+// GenerateAPIKey returns a new random key using the prefix growthbook uses for the type of the key
+func GenerateAPIKey(apiKey APIKey) (string, error) {
+	prefix := fmt.Sprintf("secret_%s_", apiKey.Role)
+	if apiKey.UserID != "" {
+		prefix = "secret_user_"
+	}
+
+	return generateKey(prefix, 32)
+}
+
+func DeleteAPIKey(ctx context.Context, apiKey APIKey, db storage.Database) error {
+	col := db.Collection("apikeys")
+	filter := bson.M{
+		"id":           apiKey.ID,
+		"organization": apiKey.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+// GetAPIKey returns the stored api key document
+func GetAPIKey(ctx context.Context, apiKey APIKey, db storage.Database) (APIKey, error) {
+	var existing APIKey
+	result, err := db.Collection("apikeys").FindOne(ctx, bson.M{
+		"id":           apiKey.ID,
+		"organization": apiKey.Organization,
+	})
+	if err != nil {
+		return existing, err
+	}
+
+	return existing, result.Decode(&existing)
+}
+
+// UpdateAPIKey creates or updates an api key.
+// A new key is generated for new documents if none is given, the key of existing documents is only replaced if a key is given.
+func UpdateAPIKey(ctx context.Context, apiKey APIKey, db storage.Database) error {
+	col := db.Collection("apikeys")
+	filter := bson.M{
+		"id":           apiKey.ID,
+		"organization": apiKey.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, apiKey, documentUpdate[APIKey]{
+		name:  fmt.Sprintf("api key %s in organization %s", apiKey.ID, apiKey.Organization),
+		merge: mergeFunc(mergeAPIKey),
+		create: func(apiKey APIKey) (APIKey, error) {
+			if apiKey.Key == "" {
+				key, err := GenerateAPIKey(apiKey)
+				if err != nil {
+					return apiKey, err
+				}
+
+				apiKey.Key = key
+			}
+
+			apiKey.DateCreated = time.Now()
+			return apiKey, nil
+		},
+	})
+
+	return err
+}
+
+func GetAPIKeyMeta(ctx context.Context, apiKey APIKey, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("apikeys"), bson.M{
+		"id":           apiKey.ID,
+		"organization": apiKey.Organization,
+	})
+}
+
+func DiffAPIKey(ctx context.Context, apiKey APIKey, db storage.Database) ([]string, error) {
+	col := db.Collection("apikeys")
+	filter := bson.M{
+		"id":           apiKey.ID,
+		"organization": apiKey.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, apiKey, mergeFunc(mergeAPIKey))
+}
+
+func mergeAPIKey(existing, apiKey APIKey) APIKey {
+	existing.ID = apiKey.ID
+	existing.Organization = apiKey.Organization
+	existing.Description = apiKey.Description
+	existing.Environment = apiKey.Environment
+	existing.Project = apiKey.Project
+	existing.Secret = apiKey.Secret
+	existing.EncryptSDK = apiKey.EncryptSDK
+	existing.Role = apiKey.Role
+	existing.UserID = apiKey.UserID
+
+	if apiKey.Key != "" {
+		existing.Key = apiKey.Key
+	}
+
+	return existing
+}
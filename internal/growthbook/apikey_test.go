@@ -0,0 +1,218 @@
+package growthbook
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAPIKeyFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookAPIKey{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookAPIKeySpec{
+			Description: "foo",
+			Environment: "production",
+		},
+	}
+
+	k := &APIKey{}
+	k.FromV1beta1(apiSpec)
+	g.Expect(k.ID).To(Equal(apiSpec.Name))
+	g.Expect(k.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(k.Environment).To(Equal(apiSpec.Spec.Environment))
+	g.Expect(k.Secret).To(BeTrue())
+	g.Expect(k.Role).To(Equal("readonly"))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Role = v1beta1.APIKeyRoleAdmin
+	k.FromV1beta1(apiSpec)
+	g.Expect(k.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(k.Role).To(Equal("admin"))
+
+	apiSpec.Spec.User = "user"
+	k.FromV1beta1(apiSpec)
+	g.Expect(k.Role).To(Equal("user"))
+}
+
+func TestGenerateAPIKey(t *testing.T) {
+	g := NewWithT(t)
+
+	key, err := GenerateAPIKey(APIKey{Role: "readonly"})
+	g.Expect(err).To(BeNil())
+	g.Expect(strings.HasPrefix(key, "secret_readonly_")).To(BeTrue())
+
+	key, err = GenerateAPIKey(APIKey{Role: "admin"})
+	g.Expect(err).To(BeNil())
+	g.Expect(strings.HasPrefix(key, "secret_admin_")).To(BeTrue())
+
+	other, err := GenerateAPIKey(APIKey{Role: "admin"})
+	g.Expect(err).To(BeNil())
+	g.Expect(other).NotTo(Equal(key))
+
+	key, err = GenerateAPIKey(APIKey{Role: "user", UserID: "user"})
+	g.Expect(err).To(BeNil())
+	g.Expect(strings.HasPrefix(key, "secret_user_")).To(BeTrue())
+}
+
+func TestAPIKeyDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteAPIKey(context.TODO(), APIKey{ID: "key", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "key",
+		"organization": "org",
+	}))
+}
+
+func TestAPIKeyCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc APIKey
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(APIKey)
+			return nil
+		},
+	}
+
+	err := UpdateAPIKey(context.TODO(), APIKey{ID: "key", Organization: "org", Role: "readonly", Secret: true}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("key"))
+	g.Expect(strings.HasPrefix(insertedDoc.Key, "secret_readonly_")).To(BeTrue())
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestAPIKeyUpdateRetainsKey(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*APIKey).ID = "key"
+					dst.(*APIKey).Organization = "org"
+					dst.(*APIKey).Key = "secret_readonly_existing"
+					dst.(*APIKey).Description = "old"
+					dst.(*APIKey).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	err := UpdateAPIKey(context.TODO(), APIKey{ID: "key", Organization: "org", Description: "new"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("description").StringValue()).To(Equal("new"))
+	g.Expect(updateBSON.Lookup("key").StringValue()).To(Equal("secret_readonly_existing"))
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "key",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestAPIKeyRotate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateDoc interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*APIKey).ID = "key"
+					dst.(*APIKey).Organization = "org"
+					dst.(*APIKey).Key = "secret_readonly_existing"
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	err := UpdateAPIKey(context.TODO(), APIKey{ID: "key", Organization: "org", Key: "secret_readonly_rotated"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("key").StringValue()).To(Equal("secret_readonly_rotated"))
+}
+
+func TestAPIKeyNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*APIKey).ID = "key"
+					dst.(*APIKey).Organization = "org"
+					dst.(*APIKey).Key = "secret_readonly_existing"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateAPIKey(context.TODO(), APIKey{ID: "key", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestAPIKeyDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*APIKey).ID = "key"
+					dst.(*APIKey).Organization = "org"
+					dst.(*APIKey).Key = "secret_readonly_existing"
+					dst.(*APIKey).Role = "admin"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffAPIKey(context.TODO(), APIKey{ID: "key", Organization: "org", Role: "readonly"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"role"}))
+}
@@ -0,0 +1,99 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Archetype is a saved set of user attributes used to preview how features evaluate
+type Archetype struct {
+	ID           string    `bson:"id"`
+	Organization string    `bson:"organization"`
+	Name         string    `bson:"name"`
+	Description  string    `bson:"description"`
+	Owner        string    `bson:"owner"`
+	IsPublic     bool      `bson:"isPublic"`
+	Attributes   string    `bson:"attributes"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	DateUpdated  time.Time `bson:"dateUpdated"`
+	Revision     int       `bson:"__v"`
+}
+
+func (a *Archetype) FromV1beta1(archetype v1beta1.GrowthbookArchetype) *Archetype {
+	a.ID = archetype.GetID()
+	a.Name = archetype.GetName()
+	a.Description = archetype.Spec.Description
+	a.Owner = archetype.Spec.Owner
+	a.IsPublic = archetype.Spec.Public
+	a.Attributes = archetype.Spec.Attributes
+
+	if a.Attributes == "" {
+		a.Attributes = "{}"
+	}
+
+	return a
+}
+
+func DeleteArchetype(ctx context.Context, archetype Archetype, db storage.Database) error {
+	col := db.Collection("archetypes")
+	filter := bson.M{
+		"id":           archetype.ID,
+		"organization": archetype.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateArchetype(ctx context.Context, archetype Archetype, db storage.Database) error {
+	col := db.Collection("archetypes")
+	filter := bson.M{
+		"id":           archetype.ID,
+		"organization": archetype.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, archetype, documentUpdate[Archetype]{
+		name:  fmt.Sprintf("archetype %s in organization %s", archetype.ID, archetype.Organization),
+		merge: mergeFunc(mergeArchetype),
+		create: func(archetype Archetype) (Archetype, error) {
+			archetype.DateCreated = time.Now()
+			archetype.DateUpdated = archetype.DateCreated
+			return archetype, nil
+		},
+	})
+
+	return err
+}
+
+func GetArchetypeMeta(ctx context.Context, archetype Archetype, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("archetypes"), bson.M{
+		"id":           archetype.ID,
+		"organization": archetype.Organization,
+	})
+}
+
+func DiffArchetype(ctx context.Context, archetype Archetype, db storage.Database) ([]string, error) {
+	col := db.Collection("archetypes")
+	filter := bson.M{
+		"id":           archetype.ID,
+		"organization": archetype.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, archetype, mergeFunc(mergeArchetype))
+}
+
+func mergeArchetype(existing, archetype Archetype) Archetype {
+	existing.ID = archetype.ID
+	existing.Organization = archetype.Organization
+	existing.Name = archetype.Name
+	existing.Description = archetype.Description
+	existing.Owner = archetype.Owner
+	existing.IsPublic = archetype.IsPublic
+	existing.Attributes = archetype.Attributes
+
+	return existing
+}
@@ -0,0 +1,169 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestArchetypeFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookArchetype{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookArchetypeSpec{
+			Description: "foo",
+			Owner:       "u_owner",
+			Public:      true,
+			Attributes:  `{"country":"CH"}`,
+		},
+	}
+
+	a := &Archetype{}
+	a.FromV1beta1(apiSpec)
+	g.Expect(a.ID).To(Equal(apiSpec.Name))
+	g.Expect(a.Name).To(Equal(apiSpec.Name))
+	g.Expect(a.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(a.Owner).To(Equal("u_owner"))
+	g.Expect(a.IsPublic).To(BeTrue())
+	g.Expect(a.Attributes).To(Equal(`{"country":"CH"}`))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Attributes = ""
+	a.FromV1beta1(apiSpec)
+	g.Expect(a.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(a.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(a.Attributes).To(Equal("{}"))
+}
+
+func TestArchetypeDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteArchetype(context.TODO(), Archetype{ID: "archetype", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "archetype",
+		"organization": "org",
+	}))
+}
+
+func TestArchetypeCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Archetype
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Archetype)
+			return nil
+		},
+	}
+
+	err := UpdateArchetype(context.TODO(), Archetype{ID: "archetype", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("archetype"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestArchetypeNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Archetype).ID = "archetype"
+					dst.(*Archetype).Organization = "org"
+					dst.(*Archetype).Attributes = `{"country":"DE"}`
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateArchetype(context.TODO(), Archetype{ID: "archetype", Organization: "org", Attributes: `{"country":"DE"}`}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestArchetypeUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Archetype).ID = "archetype"
+					dst.(*Archetype).Organization = "org"
+					dst.(*Archetype).Attributes = `{"country":"CH"}`
+					dst.(*Archetype).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateArchetype(context.TODO(), Archetype{ID: "archetype", Organization: "org", Attributes: `{"country":"DE"}`}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("attributes").StringValue()).To(Equal(`{"country":"DE"}`))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "archetype",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestArchetypeDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Archetype).ID = "archetype"
+					dst.(*Archetype).Organization = "org"
+					dst.(*Archetype).Attributes = `{"country":"CH"}`
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffArchetype(context.TODO(), Archetype{ID: "archetype", Organization: "org", Attributes: `{"country":"DE"}`}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"attributes"}))
+}
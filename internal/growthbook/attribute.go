@@ -0,0 +1,278 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
+)
+
+// Attribute is an entry of the attribute schema stored in the organization settings
+type Attribute struct {
+	Organization  string   `bson:"-"`
+	Property      string   `bson:"property"`
+	Datatype      string   `bson:"datatype"`
+	Description   string   `bson:"description,omitempty"`
+	HashAttribute bool     `bson:"hashAttribute"`
+	Enum          string   `bson:"enum,omitempty"`
+	Archived      bool     `bson:"archived"`
+	Projects      []string `bson:"projects"`
+
+	// Extra retains fields which are not managed by the controller
+	Extra bson.M `bson:",inline"`
+}
+
+// errOrganizationNotFound is returned if the organization holding the attribute schema does not exist
+var errOrganizationNotFound = errors.New("organization not found")
+
+type organizationAttributeSchema struct {
+	Settings struct {
+		AttributeSchema []Attribute `bson:"attributeSchema"`
+	} `bson:"settings"`
+	Revision int `bson:"__v"`
+}
+
+func (a *Attribute) FromV1beta1(attribute v1beta1.GrowthbookAttribute) *Attribute {
+	a.Property = attribute.GetProperty()
+	a.Datatype = string(attribute.Spec.Datatype)
+	a.Description = attribute.Spec.Description
+	a.HashAttribute = attribute.Spec.HashAttribute
+	a.Enum = strings.Join(attribute.Spec.Enum, ",")
+	a.Archived = attribute.Spec.Archived
+
+	// Projects are referenced by resource name and need to be resolved by the caller
+	a.Projects = []string{}
+
+	return a
+}
+
+func DeleteAttribute(ctx context.Context, attribute Attribute, db storage.Database) error {
+	err := updateAttributeSchema(ctx, attribute, db, func(schema []Attribute) []Attribute {
+		return slices.DeleteFunc(schema, func(a Attribute) bool {
+			return a.Property == attribute.Property
+		})
+	})
+
+	if errors.Is(err, errOrganizationNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func ArchiveAttribute(ctx context.Context, attribute Attribute, db storage.Database) error {
+	err := updateAttributeSchema(ctx, attribute, db, func(schema []Attribute) []Attribute {
+		for i, a := range schema {
+			if a.Property == attribute.Property {
+				schema[i].Archived = true
+			}
+		}
+
+		return schema
+	})
+
+	if errors.Is(err, errOrganizationNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func UpdateAttribute(ctx context.Context, attribute Attribute, db storage.Database) error {
+	return updateAttributeSchema(ctx, attribute, db, func(schema []Attribute) []Attribute {
+		for i, a := range schema {
+			if a.Property == attribute.Property {
+				schema[i] = mergeAttribute(a, attribute)
+				return schema
+			}
+		}
+
+		return append(schema, mergeAttribute(Attribute{}, attribute))
+	})
+}
+
+// updateAttributeSchema applies a change to the attribute schema of an organization, all other settings are retained
+func updateAttributeSchema(ctx context.Context, attribute Attribute, db storage.Database, change func(schema []Attribute) []Attribute) error {
+	col := db.Collection("organizations")
+	filter := bson.M{
+		"id": attribute.Organization,
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var existing organizationAttributeSchema
+		result, err := col.FindOne(ctx, filter)
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", errOrganizationNotFound, attribute.Organization)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := result.Decode(&existing); err != nil {
+			return err
+		}
+
+		current := existing.Settings.AttributeSchema
+		schema := change(slices.Clone(current))
+
+		equal, err := equalAttributeSchema(current, schema)
+		if err != nil || equal {
+			return err
+		}
+
+		if schema == nil {
+			schema = []Attribute{}
+		}
+
+		update := bson.D{
+			{Key: "$set", Value: bson.M{
+				"settings.attributeSchema": schema,
+				"__v":                      existing.Revision + 1,
+			}},
+		}
+
+		err = col.UpdateOne(ctx, revisionFilter(filter, existing.Revision), update)
+		if errors.Is(err, storage.ErrNoMatch) {
+			continue
+		}
+
+		return err
+	}
+
+	return fmt.Errorf("%w: attribute %s in organization %s", ErrConflict, attribute.Property, attribute.Organization)
+}
+
+func equalAttributeSchema(a, b []Attribute) (bool, error) {
+	if len(a) != len(b) {
+		return false, nil
+	}
+
+	for i := range a {
+		fields, err := diffAttribute(a[i], b[i])
+		if err != nil || len(fields) > 0 {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// GetAttributeSchema returns the attribute schema of an organization.
+// An organization which does not exist yet has an empty schema.
+func GetAttributeSchema(ctx context.Context, organization string, db storage.Database) ([]Attribute, error) {
+	var existing organizationAttributeSchema
+	result, err := db.Collection("organizations").FindOne(ctx, bson.M{
+		"id": organization,
+	})
+	if isNotFound(err) {
+		return []Attribute{}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := result.Decode(&existing); err != nil {
+		return nil, err
+	}
+
+	return existing.Settings.AttributeSchema, nil
+}
+
+func GetAttributeMeta(ctx context.Context, attribute Attribute, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("organizations"), bson.M{
+		"id": attribute.Organization,
+	})
+}
+
+func DiffAttribute(ctx context.Context, attribute Attribute, db storage.Database) ([]string, error) {
+	schema, err := GetAttributeSchema(ctx, attribute.Organization, db)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, existing := range schema {
+		if existing.Property == attribute.Property {
+			return diffAttribute(existing, mergeAttribute(existing, attribute))
+		}
+	}
+
+	return nil, nil
+}
+
+func diffAttribute(existing, attribute Attribute) ([]string, error) {
+	existingBson, err := bson.Marshal(existing)
+	if err != nil {
+		return nil, err
+	}
+
+	updateBson, err := bson.Marshal(attribute)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffDocuments(existingBson, updateBson)
+}
+
+func mergeAttribute(existing, attribute Attribute) Attribute {
+	existing.Property = attribute.Property
+	existing.Datatype = attribute.Datatype
+	existing.Description = attribute.Description
+	existing.HashAttribute = attribute.HashAttribute
+	existing.Enum = attribute.Enum
+	existing.Archived = attribute.Archived
+	existing.Projects = attribute.Projects
+
+	return existing
+}
+
+// ConditionAttributes returns the attributes used by a targeting condition.
+// Logical operators are followed, the values of attribute operators are not.
+func ConditionAttributes(condition string) ([]string, error) {
+	if strings.TrimSpace(condition) == "" {
+		return nil, nil
+	}
+
+	var doc bson.M
+	if err := bson.UnmarshalExtJSON([]byte(condition), false, &doc); err != nil {
+		return nil, fmt.Errorf("invalid condition: %w", err)
+	}
+
+	var attributes []string
+	collectConditionAttributes(doc, &attributes)
+	slices.Sort(attributes)
+
+	return slices.Compact(attributes), nil
+}
+
+func collectConditionAttributes(condition bson.M, attributes *[]string) {
+	for key, value := range condition {
+		switch key {
+		case "$and", "$or", "$nor":
+			conditions, ok := value.(bson.A)
+			if !ok {
+				continue
+			}
+
+			for _, c := range conditions {
+				if c, ok := c.(bson.M); ok {
+					collectConditionAttributes(c, attributes)
+				}
+			}
+		case "$not":
+			if c, ok := value.(bson.M); ok {
+				collectConditionAttributes(c, attributes)
+			}
+		default:
+			if !strings.HasPrefix(key, "$") {
+				*attributes = append(*attributes, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAttributeFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookAttribute{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "country",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookAttributeSpec{
+			Datatype:    v1beta1.AttributeDatatypeEnum,
+			Enum:        []string{"CH", "DE"},
+			Description: "foo",
+		},
+	}
+
+	a := &Attribute{}
+	a.FromV1beta1(apiSpec)
+	g.Expect(a.Property).To(Equal(apiSpec.Name))
+	g.Expect(a.Datatype).To(Equal("enum"))
+	g.Expect(a.Enum).To(Equal("CH,DE"))
+	g.Expect(a.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(a.Projects).To(Equal([]string{}))
+
+	apiSpec.Spec.Property = "custom"
+	a.FromV1beta1(apiSpec)
+	g.Expect(a.Property).To(Equal(apiSpec.Spec.Property))
+}
+
+func attributeSchemaResult(revision int, schema ...Attribute) *MockResult {
+	return &MockResult{
+		decode: func(dst interface{}) error {
+			b, err := bson.Marshal(bson.M{
+				"id":       "org",
+				"__v":      revision,
+				"settings": bson.M{"attributeSchema": schema, "environments": bson.A{}},
+			})
+			if err != nil {
+				return err
+			}
+
+			return bson.Unmarshal(b, dst)
+		},
+	}
+}
+
+func TestAttributeUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter bson.M
+	var updateDoc bson.D
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return attributeSchemaResult(3,
+				Attribute{Property: "id", Datatype: "string", HashAttribute: true, Projects: []string{}},
+				Attribute{Property: "country", Datatype: "string", Projects: []string{}, Extra: bson.M{"format": "isoCountryCode"}},
+			), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter.(bson.M)
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := UpdateAttribute(context.TODO(), Attribute{Organization: "org", Property: "country", Datatype: "enum", Enum: "CH,DE", Projects: []string{}}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateFilter).To(Equal(bson.M{"id": "org", "__v": 3}))
+
+	set := updateDoc[0].Value.(bson.M)
+	g.Expect(set["__v"]).To(Equal(4))
+	g.Expect(set["settings.attributeSchema"]).To(Equal([]Attribute{
+		{Property: "id", Datatype: "string", HashAttribute: true, Projects: []string{}},
+		{Property: "country", Datatype: "enum", Enum: "CH,DE", Projects: []string{}, Extra: bson.M{"format": "isoCountryCode"}},
+	}))
+}
+
+func TestAttributeCreate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateDoc bson.D
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return attributeSchemaResult(0), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := UpdateAttribute(context.TODO(), Attribute{Organization: "org", Property: "id", Datatype: "string", Projects: []string{}}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateDoc[0].Value.(bson.M)["settings.attributeSchema"]).To(Equal([]Attribute{
+		{Property: "id", Datatype: "string", Projects: []string{}},
+	}))
+}
+
+func TestAttributeNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return attributeSchemaResult(1, Attribute{Property: "id", Datatype: "string", Projects: []string{}}), nil
+		},
+	}
+
+	err := UpdateAttribute(context.TODO(), Attribute{Organization: "org", Property: "id", Datatype: "string", Projects: []string{}}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestAttributeUpdateConflictExceeded(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return attributeSchemaResult(1), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			return storage.ErrNoMatch
+		},
+	}
+
+	err := UpdateAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)
+	g.Expect(errors.Is(err, ErrConflict)).To(BeTrue())
+}
+
+func TestAttributeDeleteAndArchive(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateDoc bson.D
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return attributeSchemaResult(1,
+				Attribute{Property: "id", Datatype: "string", Projects: []string{}},
+				Attribute{Property: "country", Datatype: "string", Projects: []string{}},
+			), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := DeleteAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateDoc[0].Value.(bson.M)["settings.attributeSchema"]).To(Equal([]Attribute{
+		{Property: "country", Datatype: "string", Projects: []string{}},
+	}))
+
+	err = ArchiveAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateDoc[0].Value.(bson.M)["settings.attributeSchema"]).To(Equal([]Attribute{
+		{Property: "id", Datatype: "string", Archived: true, Projects: []string{}},
+		{Property: "country", Datatype: "string", Projects: []string{}},
+	}))
+}
+
+func TestAttributeOrganizationNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+	}
+
+	g.Expect(UpdateAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)).NotTo(BeNil())
+	g.Expect(DeleteAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)).To(BeNil())
+	g.Expect(ArchiveAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)).To(BeNil())
+}
+
+func TestAttributeDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return attributeSchemaResult(1, Attribute{Property: "id", Datatype: "number", Projects: []string{}}), nil
+		},
+	}
+
+	fields, err := DiffAttribute(context.TODO(), Attribute{Organization: "org", Property: "id", Datatype: "string", Projects: []string{}}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"datatype"}))
+
+	fields, err = DiffAttribute(context.TODO(), Attribute{Organization: "org", Property: "other"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(BeEmpty())
+}
+
+func TestGetAttributeSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+	}
+
+	schema, err := GetAttributeSchema(context.TODO(), "org", db)
+	g.Expect(err).To(BeNil())
+	g.Expect(schema).To(BeEmpty())
+
+	fields, err := DiffAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(BeEmpty())
+
+	db.FindOne = func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+		return nil, errors.New("connection lost")
+	}
+
+	_, err = GetAttributeSchema(context.TODO(), "org", db)
+	g.Expect(err).NotTo(BeNil())
+
+	_, err = DiffAttribute(context.TODO(), Attribute{Organization: "org", Property: "id"}, db)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestConditionAttributes(t *testing.T) {
+	g := NewWithT(t)
+
+	attributes, err := ConditionAttributes(`{"country": {"$in": ["CH", "DE"]}, "$or": [{"id": "1"}, {"$not": {"loggedIn": true}}], "tags": {"$elemMatch": {"name": "x"}}}`)
+	g.Expect(err).To(BeNil())
+	g.Expect(attributes).To(Equal([]string{"country", "id", "loggedIn", "tags"}))
+
+	attributes, err = ConditionAttributes("")
+	g.Expect(err).To(BeNil())
+	g.Expect(attributes).To(BeEmpty())
+
+	_, err = ConditionAttributes("{invalid")
+	g.Expect(err).NotTo(BeNil())
+}
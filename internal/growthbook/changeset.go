@@ -0,0 +1,118 @@
+package growthbook
+
+import (
+	"context"
+	"sort"
+
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type FeatureEvent string
+
+var (
+	FeatureEventCreated FeatureEvent = "feature.created"
+	FeatureEventUpdated FeatureEvent = "feature.updated"
+	FeatureEventDeleted FeatureEvent = "feature.deleted"
+)
+
+// FeatureChange describes a feature which has been written to growthbook
+type FeatureChange struct {
+	Organization string
+	ID           string
+	Event        FeatureEvent
+	// Feature is the stored feature after the change, the removed feature if it has been deleted
+	Feature Feature
+	// Previous is the stored feature before an update, nil for created and deleted features
+	Previous *Feature
+	// Environments are the environments affected by the change
+	Environments []string
+}
+
+// ChangeSet collects the changes written to growthbook during a reconciliation.
+// It is used to invalidate the sdk payloads cached by growthbook and to notify webhooks once all changes are written.
+// The zero value is ready to use.
+type ChangeSet struct {
+	environments map[string]map[string]struct{}
+	features     []FeatureChange
+}
+
+// Invalidate marks the cached payloads of the given organization and environments as stale
+func (c *ChangeSet) Invalidate(organization string, environments ...string) {
+	if len(environments) == 0 {
+		return
+	}
+
+	if c.environments == nil {
+		c.environments = make(map[string]map[string]struct{})
+	}
+
+	if _, ok := c.environments[organization]; !ok {
+		c.environments[organization] = make(map[string]struct{})
+	}
+
+	for _, env := range environments {
+		c.environments[organization][env] = struct{}{}
+	}
+}
+
+// FeatureChanged records a feature which has been created, updated or deleted
+func (c *ChangeSet) FeatureChanged(change FeatureChange) {
+	c.features = append(c.features, change)
+}
+
+// Merge adds all changes of another change set
+func (c *ChangeSet) Merge(other ChangeSet) {
+	for org, envs := range other.environments {
+		for env := range envs {
+			c.Invalidate(org, env)
+		}
+	}
+
+	c.features = append(c.features, other.features...)
+}
+
+// Environments returns the sorted environments with stale payloads by organization
+func (c *ChangeSet) Environments() map[string][]string {
+	result := make(map[string][]string, len(c.environments))
+	for org, envs := range c.environments {
+		for env := range envs {
+			result[org] = append(result[org], env)
+		}
+
+		sort.Strings(result[org])
+	}
+
+	return result
+}
+
+// FeatureChanges returns all recorded feature changes
+func (c *ChangeSet) FeatureChanges() []FeatureChange {
+	return c.features
+}
+
+// Flush deletes all stale payloads with a single query per organization
+func (c *ChangeSet) Flush(ctx context.Context, db storage.Database) error {
+	col := db.Collection("sdkpayloads")
+	environments := c.Environments()
+
+	var orgs []string
+	for org := range environments {
+		orgs = append(orgs, org)
+	}
+
+	sort.Strings(orgs)
+
+	for _, org := range orgs {
+		filter := bson.M{
+			"organization": org,
+			"environment":  bson.M{"$in": environments[org]},
+		}
+
+		if err := col.DeleteMany(ctx, filter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestChangeSetFlush(t *testing.T) {
+	g := NewWithT(t)
+
+	var filters []bson.M
+	db := &MockDatabase{
+		DeleteMany: func(ctx context.Context, filter interface{}) error {
+			filters = append(filters, filter.(bson.M))
+			return nil
+		},
+	}
+
+	changes := &ChangeSet{}
+	changes.Invalidate("org-b", "production")
+	changes.Invalidate("org-a", "dev", "production")
+	changes.Invalidate("org-a", "dev")
+	changes.Invalidate("org-c")
+
+	err := changes.Flush(context.TODO(), db)
+	g.Expect(err).To(BeNil())
+	g.Expect(filters).To(Equal([]bson.M{
+		{
+			"organization": "org-a",
+			"environment":  bson.M{"$in": []string{"dev", "production"}},
+		},
+		{
+			"organization": "org-b",
+			"environment":  bson.M{"$in": []string{"production"}},
+		},
+	}))
+}
+
+func TestChangeSetFlushError(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		DeleteMany: func(ctx context.Context, filter interface{}) error {
+			return errors.New("failed")
+		},
+	}
+
+	changes := &ChangeSet{}
+	changes.Invalidate("org", "dev")
+
+	err := changes.Flush(context.TODO(), db)
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestChangeSetEnvironments(t *testing.T) {
+	g := NewWithT(t)
+
+	changes := &ChangeSet{}
+	g.Expect(changes.Environments()).To(BeEmpty())
+
+	changes.Invalidate("org", "production", "dev")
+	g.Expect(changes.Environments()).To(Equal(map[string][]string{
+		"org": {"dev", "production"},
+	}))
+}
+
+func TestChangeSetFeatureChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	changes := &ChangeSet{}
+	changes.FeatureChanged(FeatureChange{Organization: "org", ID: "feature-a", Event: FeatureEventCreated})
+	changes.FeatureChanged(FeatureChange{Organization: "org", ID: "feature-b", Event: FeatureEventDeleted})
+
+	g.Expect(changes.FeatureChanges()).To(Equal([]FeatureChange{
+		{Organization: "org", ID: "feature-a", Event: FeatureEventCreated},
+		{Organization: "org", ID: "feature-b", Event: FeatureEventDeleted},
+	}))
+}
+
+func TestChangeSetMerge(t *testing.T) {
+	g := NewWithT(t)
+
+	changes := &ChangeSet{}
+	changes.Invalidate("org", "dev")
+	changes.FeatureChanged(FeatureChange{Organization: "org", ID: "feature-a", Event: FeatureEventCreated})
+
+	other := ChangeSet{}
+	other.Invalidate("org", "production")
+	other.Invalidate("other-org", "dev")
+	other.FeatureChanged(FeatureChange{Organization: "org", ID: "feature-b", Event: FeatureEventUpdated})
+
+	changes.Merge(other)
+	g.Expect(changes.Environments()).To(Equal(map[string][]string{
+		"org":       {"dev", "production"},
+		"other-org": {"dev"},
+	}))
+	g.Expect(changes.FeatureChanges()).To(Equal([]FeatureChange{
+		{Organization: "org", ID: "feature-a", Event: FeatureEventCreated},
+		{Organization: "org", ID: "feature-b", Event: FeatureEventUpdated},
+	}))
+}
@@ -0,0 +1,263 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/md5"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// DataSource is a connection to a data warehouse used to analyze experiments
+type DataSource struct {
+	ID           string    `bson:"id"`
+	Organization string    `bson:"organization"`
+	Name         string    `bson:"name"`
+	Description  string    `bson:"description"`
+	Type         string    `bson:"type"`
+	Projects     []string  `bson:"projects"`
+	Params       string    `bson:"params"`
+	Settings     bson.D    `bson:"settings"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	DateUpdated  time.Time `bson:"dateUpdated"`
+	Revision     int       `bson:"__v"`
+
+	// ConnectionParams are encrypted into Params using the EncryptionKey.
+	// The stored params are only replaced if the decrypted params differ.
+	ConnectionParams map[string]interface{} `bson:"-"`
+	EncryptionKey    string                 `bson:"-"`
+
+	// ExposureQueries replace settings.queries.exposure if not nil, other settings are retained
+	ExposureQueries []ExposureQuery `bson:"-"`
+}
+
+type ExposureQuery struct {
+	ID          string   `bson:"id"`
+	Name        string   `bson:"name"`
+	Description string   `bson:"description"`
+	UserIDType  string   `bson:"userIdType"`
+	Query       string   `bson:"query"`
+	Dimensions  []string `bson:"dimensions"`
+	HasNameCol  bool     `bson:"hasNameCol"`
+}
+
+func (d *DataSource) FromV1beta1(dataSource v1beta1.GrowthbookDataSource) *DataSource {
+	d.ID = dataSource.GetID()
+	d.Name = dataSource.GetName()
+	d.Description = dataSource.Spec.Description
+	d.Type = dataSource.Spec.Type
+
+	// Projects are referenced by resource name and need to be resolved by the caller
+	d.Projects = append([]string{}, dataSource.Spec.Projects...)
+
+	if dataSource.Spec.Queries != nil {
+		d.ExposureQueries = []ExposureQuery{}
+	}
+
+	for _, query := range dataSource.Spec.Queries {
+		userIDType := query.UserIDType
+		if userIDType == "" {
+			userIDType = "user_id"
+		}
+
+		d.ExposureQueries = append(d.ExposureQueries, ExposureQuery{
+			ID:          query.ID,
+			Name:        query.Name,
+			Description: query.Description,
+			UserIDType:  userIDType,
+			Query:       query.Query,
+			Dimensions:  append([]string{}, query.Dimensions...),
+			HasNameCol:  query.HasNameCol,
+		})
+	}
+
+	return d
+}
+
+// EncryptParams encrypts the connection params the same way the growthbook backend does (crypto-js AES using a passphrase).
+// The result is the base64 encoded OpenSSL format consisting of the Salted__ prefix, an 8 byte salt and the AES-256-CBC ciphertext.
+func EncryptParams(params map[string]interface{}, encryptionKey string) (string, error) {
+	plaintext, err := json.Marshal(params)
+	if err != nil {
+		return "", err
+	}
+
+	salt := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+
+	key, iv := deriveKey([]byte(encryptionKey), salt)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", err
+	}
+
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	plaintext = append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+
+	ciphertext := make([]byte, len(plaintext))
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, plaintext)
+
+	out := append([]byte("Salted__"), salt...)
+	out = append(out, ciphertext...)
+	return base64.StdEncoding.EncodeToString(out), nil
+}
+
+// DecryptParams decrypts connection params encrypted by EncryptParams or the growthbook backend
+func DecryptParams(params string, encryptionKey string) (map[string]interface{}, error) {
+	data, err := base64.StdEncoding.DecodeString(params)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) < 16 || !bytes.HasPrefix(data, []byte("Salted__")) {
+		return nil, errors.New("params are not encrypted using a passphrase")
+	}
+
+	ciphertext := data[16:]
+	if len(ciphertext) == 0 || len(ciphertext)%aes.BlockSize != 0 {
+		return nil, errors.New("invalid params ciphertext length")
+	}
+
+	key, iv := deriveKey([]byte(encryptionKey), data[8:16])
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plaintext, ciphertext)
+
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, errors.New("failed to decrypt params, the encryption key might be wrong")
+	}
+
+	var decrypted map[string]interface{}
+	if err := json.Unmarshal(plaintext[:len(plaintext)-padding], &decrypted); err != nil {
+		return nil, fmt.Errorf("failed to decrypt params, the encryption key might be wrong: %w", err)
+	}
+
+	return decrypted, nil
+}
+
+// deriveKey derives the AES-256 key and iv from a passphrase using the OpenSSL EVP_BytesToKey scheme with MD5
+func deriveKey(passphrase, salt []byte) ([]byte, []byte) {
+	var derived, block []byte
+	for len(derived) < 48 {
+		h := md5.New()
+		h.Write(block)
+		h.Write(passphrase)
+		h.Write(salt)
+		block = h.Sum(nil)
+		derived = append(derived, block...)
+	}
+
+	return derived[:32], derived[32:48]
+}
+
+func DeleteDataSource(ctx context.Context, dataSource DataSource, db storage.Database) error {
+	col := db.Collection("datasources")
+	filter := bson.M{
+		"id":           dataSource.ID,
+		"organization": dataSource.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateDataSource(ctx context.Context, dataSource DataSource, db storage.Database) error {
+	col := db.Collection("datasources")
+	filter := bson.M{
+		"id":           dataSource.ID,
+		"organization": dataSource.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, dataSource, documentUpdate[DataSource]{
+		name:  fmt.Sprintf("data source %s in organization %s", dataSource.ID, dataSource.Organization),
+		merge: mergeDataSource,
+		create: func(dataSource DataSource) (DataSource, error) {
+			dataSource, err := mergeDataSource(DataSource{}, dataSource)
+			if err != nil {
+				return dataSource, err
+			}
+
+			dataSource.DateCreated = time.Now()
+			dataSource.DateUpdated = dataSource.DateCreated
+			return dataSource, nil
+		},
+	})
+
+	return err
+}
+
+func GetDataSourceMeta(ctx context.Context, dataSource DataSource, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("datasources"), bson.M{
+		"id":           dataSource.ID,
+		"organization": dataSource.Organization,
+	})
+}
+
+func DiffDataSource(ctx context.Context, dataSource DataSource, db storage.Database) ([]string, error) {
+	col := db.Collection("datasources")
+	filter := bson.M{
+		"id":           dataSource.ID,
+		"organization": dataSource.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, dataSource, mergeDataSource)
+}
+
+func mergeDataSource(existing, dataSource DataSource) (DataSource, error) {
+	existing.ID = dataSource.ID
+	existing.Organization = dataSource.Organization
+	existing.Name = dataSource.Name
+	existing.Description = dataSource.Description
+	existing.Type = dataSource.Type
+	existing.Projects = dataSource.Projects
+
+	if dataSource.Settings != nil {
+		existing.Settings = dataSource.Settings
+	}
+
+	if dataSource.ExposureQueries != nil {
+		queries, _ := getSetting(existing.Settings, "queries").(bson.D)
+		existing.Settings = setSetting(existing.Settings, "queries", setSetting(queries, "exposure", dataSource.ExposureQueries))
+	}
+
+	// The params are encrypted using a random salt, they are only replaced if their content changed
+	if dataSource.ConnectionParams != nil {
+		current, err := DecryptParams(existing.Params, dataSource.EncryptionKey)
+		if err != nil || !reflect.DeepEqual(current, dataSource.ConnectionParams) {
+			existing.Params, err = EncryptParams(dataSource.ConnectionParams, dataSource.EncryptionKey)
+			if err != nil {
+				return existing, err
+			}
+		}
+	}
+
+	return existing, nil
+}
+
+// getSetting returns the value of a single setting, nil if it does not exist
+func getSetting(settings bson.D, key string) interface{} {
+	for _, setting := range settings {
+		if setting.Key == key {
+			return setting.Value
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,257 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDataSourceFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookDataSource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookDataSourceSpec{
+			Description: "foo",
+			Type:        "postgres",
+			Projects:    []string{"frontend"},
+			Queries: []v1beta1.DataSourceExposureQuery{
+				{
+					ID:    "user_id",
+					Name:  "Users",
+					Query: "SELECT * FROM exposures",
+				},
+			},
+		},
+	}
+
+	dataSource := &DataSource{}
+	dataSource.FromV1beta1(apiSpec)
+	g.Expect(dataSource.ID).To(Equal(apiSpec.Name))
+	g.Expect(dataSource.Name).To(Equal(apiSpec.Name))
+	g.Expect(dataSource.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(dataSource.Type).To(Equal("postgres"))
+	g.Expect(dataSource.Projects).To(Equal([]string{"frontend"}))
+	g.Expect(dataSource.ExposureQueries).To(Equal([]ExposureQuery{
+		{
+			ID:         "user_id",
+			Name:       "Users",
+			UserIDType: "user_id",
+			Query:      "SELECT * FROM exposures",
+			Dimensions: []string{},
+		},
+	}))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Queries = nil
+	dataSource = &DataSource{}
+	dataSource.FromV1beta1(apiSpec)
+	g.Expect(dataSource.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(dataSource.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(dataSource.ExposureQueries).To(BeNil())
+}
+
+func TestDataSourceDecryptParams(t *testing.T) {
+	g := NewWithT(t)
+
+	// Encrypted using `openssl enc -aes-256-cbc -md md5 -S 0102030405060708 -k secret-key -a`
+	params, err := DecryptParams("U2FsdGVkX18BAgMEBQYHCIYOLMpqD+w/9vLj5nnPDieaqXLagIH+3TeJRLp2yWZ3", "secret-key")
+	g.Expect(err).To(BeNil())
+	g.Expect(params).To(Equal(map[string]interface{}{
+		"host": "db",
+		"port": float64(5432),
+	}))
+
+	_, err = DecryptParams("U2FsdGVkX18BAgMEBQYHCIYOLMpqD+w/9vLj5nnPDieaqXLagIH+3TeJRLp2yWZ3", "wrong-key")
+	g.Expect(err).NotTo(BeNil())
+
+	_, err = DecryptParams("not-encrypted", "secret-key")
+	g.Expect(err).NotTo(BeNil())
+}
+
+func TestDataSourceEncryptParams(t *testing.T) {
+	g := NewWithT(t)
+
+	params := map[string]interface{}{
+		"host":     "db",
+		"password": "secret",
+	}
+
+	encrypted, err := EncryptParams(params, "secret-key")
+	g.Expect(err).To(BeNil())
+	g.Expect(encrypted).To(HavePrefix("U2FsdGVkX1"))
+
+	decrypted, err := DecryptParams(encrypted, "secret-key")
+	g.Expect(err).To(BeNil())
+	g.Expect(decrypted).To(Equal(params))
+}
+
+func TestDataSourceDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteDataSource(context.TODO(), DataSource{ID: "ds", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "ds",
+		"organization": "org",
+	}))
+}
+
+func TestDataSourceCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc DataSource
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(DataSource)
+			return nil
+		},
+	}
+
+	err := UpdateDataSource(context.TODO(), DataSource{
+		ID:               "ds",
+		Organization:     "org",
+		ConnectionParams: map[string]interface{}{"host": "db"},
+		EncryptionKey:    "secret-key",
+		ExposureQueries:  []ExposureQuery{{ID: "user_id"}},
+	}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("ds"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+	g.Expect(insertedDoc.Settings).To(Equal(bson.D{
+		{Key: "queries", Value: bson.D{
+			{Key: "exposure", Value: []ExposureQuery{{ID: "user_id"}}},
+		}},
+	}))
+
+	params, err := DecryptParams(insertedDoc.Params, "secret-key")
+	g.Expect(err).To(BeNil())
+	g.Expect(params).To(Equal(map[string]interface{}{"host": "db"}))
+}
+
+func TestDataSourceNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*DataSource).ID = "ds"
+					dst.(*DataSource).Organization = "org"
+					dst.(*DataSource).Type = "postgres"
+					dst.(*DataSource).Params = "U2FsdGVkX18BAgMEBQYHCIYOLMpqD+w/9vLj5nnPDieaqXLagIH+3TeJRLp2yWZ3"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateDataSource(context.TODO(), DataSource{
+		ID:               "ds",
+		Organization:     "org",
+		Type:             "postgres",
+		ConnectionParams: map[string]interface{}{"host": "db", "port": float64(5432)},
+		EncryptionKey:    "secret-key",
+	}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestDataSourceUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*DataSource).ID = "ds"
+					dst.(*DataSource).Organization = "org"
+					dst.(*DataSource).Type = "postgres"
+					dst.(*DataSource).Params = "U2FsdGVkX18BAgMEBQYHCIYOLMpqD+w/9vLj5nnPDieaqXLagIH+3TeJRLp2yWZ3"
+					dst.(*DataSource).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateDataSource(context.TODO(), DataSource{
+		ID:               "ds",
+		Organization:     "org",
+		Type:             "postgres",
+		ConnectionParams: map[string]interface{}{"host": "other-db", "port": float64(5432)},
+		EncryptionKey:    "secret-key",
+	}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	params, err := DecryptParams(updateBSON.Lookup("params").StringValue(), "secret-key")
+	g.Expect(err).To(BeNil())
+	g.Expect(params).To(Equal(map[string]interface{}{"host": "other-db", "port": float64(5432)}))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "ds",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestDataSourceDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*DataSource).ID = "ds"
+					dst.(*DataSource).Organization = "org"
+					dst.(*DataSource).Type = "mysql"
+					dst.(*DataSource).Params = "U2FsdGVkX18BAgMEBQYHCIYOLMpqD+w/9vLj5nnPDieaqXLagIH+3TeJRLp2yWZ3"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffDataSource(context.TODO(), DataSource{
+		ID:               "ds",
+		Organization:     "org",
+		Type:             "postgres",
+		ConnectionParams: map[string]interface{}{"host": "db", "port": float64(5432)},
+		EncryptionKey:    "secret-key",
+	}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"type"}))
+}
@@ -0,0 +1,81 @@
+package growthbook
+
+import (
+	"bytes"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
+)
+
+// Fields which are maintained by growthbook or the controller and never considered as drift
+var ignoredDiffFields = []string{"dateCreated", "dateUpdated", "__v"}
+
+// diffDocuments compares two bson documents and returns the paths of all fields which differ.
+// Embedded documents are compared field by field while arrays are compared as a whole.
+func diffDocuments(current, desired []byte) ([]string, error) {
+	var fields []string
+	if err := diffRaw("", bson.Raw(current), bson.Raw(desired), &fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func diffRaw(prefix string, current, desired bson.Raw, fields *[]string) error {
+	keys, err := documentKeys(current, desired)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range keys {
+		if prefix == "" && slices.Contains(ignoredDiffFields, key) {
+			continue
+		}
+
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+
+		currentValue, currentErr := current.LookupErr(key)
+		desiredValue, desiredErr := desired.LookupErr(key)
+
+		if currentErr != nil || desiredErr != nil {
+			*fields = append(*fields, path)
+			continue
+		}
+
+		if currentValue.Type == bson.TypeEmbeddedDocument && desiredValue.Type == bson.TypeEmbeddedDocument {
+			if err := diffRaw(path, currentValue.Document(), desiredValue.Document(), fields); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if currentValue.Type != desiredValue.Type || !bytes.Equal(currentValue.Value, desiredValue.Value) {
+			*fields = append(*fields, path)
+		}
+	}
+
+	return nil
+}
+
+// documentKeys returns the keys of both documents in order of their appearance
+func documentKeys(docs ...bson.Raw) ([]string, error) {
+	var keys []string
+	for _, doc := range docs {
+		elements, err := doc.Elements()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, element := range elements {
+			if !slices.Contains(keys, element.Key()) {
+				keys = append(keys, element.Key())
+			}
+		}
+	}
+
+	return keys, nil
+}
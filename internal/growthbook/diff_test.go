@@ -0,0 +1,74 @@
+package growthbook
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestDiffDocuments(t *testing.T) {
+	g := NewWithT(t)
+
+	current, _ := bson.Marshal(Feature{
+		ID:           "feature",
+		DefaultValue: "false",
+		DateUpdated:  time.Now(),
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"dev": {
+				Enabled: true,
+			},
+			"production": {
+				Enabled: true,
+			},
+		},
+	})
+
+	desired, _ := bson.Marshal(Feature{
+		ID:           "feature",
+		DefaultValue: "true",
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"dev": {
+				Enabled: true,
+			},
+			"production": {
+				Enabled: false,
+			},
+		},
+	})
+
+	fields, err := diffDocuments(current, desired)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"defaultValue", "environmentSettings.production.enabled"}))
+}
+
+func TestDiffDocumentsMissingFields(t *testing.T) {
+	g := NewWithT(t)
+
+	current, _ := bson.Marshal(bson.D{
+		{Key: "id", Value: "feature"},
+		{Key: "owner", Value: "someone"},
+	})
+
+	desired, _ := bson.Marshal(bson.D{
+		{Key: "id", Value: "feature"},
+		{Key: "description", Value: "description"},
+	})
+
+	fields, err := diffDocuments(current, desired)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"owner", "description"}))
+}
+
+func TestDiffDocumentsEqual(t *testing.T) {
+	g := NewWithT(t)
+
+	doc, _ := bson.Marshal(Feature{
+		ID: "feature",
+	})
+
+	fields, err := diffDocuments(doc, doc)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(BeEmpty())
+}
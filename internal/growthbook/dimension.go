@@ -0,0 +1,104 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Dimension is a user attribute experiment results can be broken down by
+type Dimension struct {
+	ID           string    `bson:"id"`
+	Organization string    `bson:"organization"`
+	Owner        string    `bson:"owner"`
+	DataSource   string    `bson:"datasource"`
+	UserIDType   string    `bson:"userIdType"`
+	Name         string    `bson:"name"`
+	Description  string    `bson:"description"`
+	SQL          string    `bson:"sql"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	DateUpdated  time.Time `bson:"dateUpdated"`
+	Revision     int       `bson:"__v"`
+}
+
+func (d *Dimension) FromV1beta1(dimension v1beta1.GrowthbookDimension) *Dimension {
+	d.ID = dimension.GetID()
+	d.Name = dimension.GetName()
+	d.Description = dimension.Spec.Description
+	d.Owner = dimension.Spec.Owner
+	d.UserIDType = dimension.Spec.UserIDType
+	d.SQL = dimension.Spec.SQL
+
+	// The data source is referenced by resource name and needs to be resolved by the caller
+	d.DataSource = dimension.Spec.DataSource
+
+	if d.UserIDType == "" {
+		d.UserIDType = "user_id"
+	}
+
+	return d
+}
+
+func DeleteDimension(ctx context.Context, dimension Dimension, db storage.Database) error {
+	col := db.Collection("dimensions")
+	filter := bson.M{
+		"id":           dimension.ID,
+		"organization": dimension.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateDimension(ctx context.Context, dimension Dimension, db storage.Database) error {
+	col := db.Collection("dimensions")
+	filter := bson.M{
+		"id":           dimension.ID,
+		"organization": dimension.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, dimension, documentUpdate[Dimension]{
+		name:  fmt.Sprintf("dimension %s in organization %s", dimension.ID, dimension.Organization),
+		merge: mergeFunc(mergeDimension),
+		create: func(dimension Dimension) (Dimension, error) {
+			dimension.DateCreated = time.Now()
+			dimension.DateUpdated = dimension.DateCreated
+			return dimension, nil
+		},
+	})
+
+	return err
+}
+
+func GetDimensionMeta(ctx context.Context, dimension Dimension, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("dimensions"), bson.M{
+		"id":           dimension.ID,
+		"organization": dimension.Organization,
+	})
+}
+
+func DiffDimension(ctx context.Context, dimension Dimension, db storage.Database) ([]string, error) {
+	col := db.Collection("dimensions")
+	filter := bson.M{
+		"id":           dimension.ID,
+		"organization": dimension.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, dimension, mergeFunc(mergeDimension))
+}
+
+func mergeDimension(existing, dimension Dimension) Dimension {
+	existing.ID = dimension.ID
+	existing.Organization = dimension.Organization
+	existing.Owner = dimension.Owner
+	existing.DataSource = dimension.DataSource
+	existing.UserIDType = dimension.UserIDType
+	existing.Name = dimension.Name
+	existing.Description = dimension.Description
+	existing.SQL = dimension.SQL
+
+	return existing
+}
@@ -0,0 +1,170 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestDimensionFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookDimension{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookDimensionSpec{
+			Description: "foo",
+			Owner:       "u_owner",
+			DataSource:  "warehouse",
+			SQL:         "SELECT * FROM users",
+		},
+	}
+
+	d := &Dimension{}
+	d.FromV1beta1(apiSpec)
+	g.Expect(d.ID).To(Equal(apiSpec.Name))
+	g.Expect(d.Name).To(Equal(apiSpec.Name))
+	g.Expect(d.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(d.Owner).To(Equal("u_owner"))
+	g.Expect(d.DataSource).To(Equal("warehouse"))
+	g.Expect(d.UserIDType).To(Equal("user_id"))
+	g.Expect(d.SQL).To(Equal(apiSpec.Spec.SQL))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.UserIDType = "anonymous_id"
+	d.FromV1beta1(apiSpec)
+	g.Expect(d.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(d.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(d.UserIDType).To(Equal("anonymous_id"))
+}
+
+func TestDimensionDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteDimension(context.TODO(), Dimension{ID: "dimension", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "dimension",
+		"organization": "org",
+	}))
+}
+
+func TestDimensionCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Dimension
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Dimension)
+			return nil
+		},
+	}
+
+	err := UpdateDimension(context.TODO(), Dimension{ID: "dimension", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("dimension"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestDimensionNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Dimension).ID = "dimension"
+					dst.(*Dimension).Organization = "org"
+					dst.(*Dimension).SQL = "SELECT * FROM customers"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateDimension(context.TODO(), Dimension{ID: "dimension", Organization: "org", SQL: "SELECT * FROM customers"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestDimensionUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Dimension).ID = "dimension"
+					dst.(*Dimension).Organization = "org"
+					dst.(*Dimension).SQL = "SELECT * FROM users"
+					dst.(*Dimension).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateDimension(context.TODO(), Dimension{ID: "dimension", Organization: "org", SQL: "SELECT * FROM customers"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("sql").StringValue()).To(Equal("SELECT * FROM customers"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "dimension",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestDimensionDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Dimension).ID = "dimension"
+					dst.(*Dimension).Organization = "org"
+					dst.(*Dimension).SQL = "SELECT * FROM users"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffDimension(context.TODO(), Dimension{ID: "dimension", Organization: "org", SQL: "SELECT * FROM customers"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"sql"}))
+}
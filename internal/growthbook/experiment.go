@@ -0,0 +1,313 @@
+package growthbook
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
+)
+
+const managedVariationIDPrefix = "var_k8s_"
+
+type ExperimentStatus string
+
+var (
+	ExperimentStatusDraft   ExperimentStatus = "draft"
+	ExperimentStatusRunning ExperimentStatus = "running"
+	ExperimentStatusStopped ExperimentStatus = "stopped"
+)
+
+type Experiment struct {
+	ID                string                `bson:"id"`
+	Organization      string                `bson:"organization"`
+	Project           string                `bson:"project"`
+	TrackingKey       string                `bson:"trackingKey"`
+	Name              string                `bson:"name"`
+	Owner             string                `bson:"owner"`
+	Description       string                `bson:"description"`
+	Hypothesis        string                `bson:"hypothesis"`
+	Tags              []string              `bson:"tags"`
+	HashAttribute     string                `bson:"hashAttribute"`
+	FallbackAttribute string                `bson:"fallbackAttribute"`
+	Status            ExperimentStatus      `bson:"status"`
+	Archived          bool                  `bson:"archived"`
+	Variations        []ExperimentVariation `bson:"variations"`
+	Phases            []ExperimentPhase     `bson:"phases"`
+	DateCreated       time.Time             `bson:"dateCreated"`
+	DateUpdated       time.Time             `bson:"dateUpdated"`
+	Revision          int                   `bson:"__v"`
+}
+
+type ExperimentVariation struct {
+	ID          string `bson:"id"`
+	Key         string `bson:"key"`
+	Name        string `bson:"name"`
+	Description string `bson:"description"`
+	Screenshots bson.A `bson:"screenshots"`
+}
+
+type ExperimentPhase struct {
+	Name             string                `bson:"name"`
+	Reason           string                `bson:"reason"`
+	DateStarted      time.Time             `bson:"dateStarted"`
+	DateEnded        *time.Time            `bson:"dateEnded,omitempty"`
+	Coverage         float64               `bson:"coverage"`
+	Condition        string                `bson:"condition"`
+	VariationWeights []float64             `bson:"variationWeights"`
+	SavedGroups      []SavedGroupTargeting `bson:"savedGroups"`
+	Namespace        NamespaceValue        `bson:"namespace"`
+	Seed             string                `bson:"seed"`
+}
+
+// FromV1beta1 converts a GrowthbookExperiment, the project and saved groups need to be resolved by the caller
+func (e *Experiment) FromV1beta1(experiment v1beta1.GrowthbookExperiment) *Experiment {
+	e.ID = experiment.GetID()
+	e.Name = experiment.GetName()
+	e.Owner = experiment.Spec.Owner
+	e.Description = experiment.Spec.Description
+	e.Hypothesis = experiment.Spec.Hypothesis
+	e.Tags = experiment.Spec.Tags
+	e.TrackingKey = experiment.Spec.TrackingKey
+	e.HashAttribute = experiment.Spec.HashAttribute
+	e.FallbackAttribute = experiment.Spec.FallbackAttribute
+	e.Status = ExperimentStatus(experiment.Spec.Status)
+
+	if e.TrackingKey == "" {
+		e.TrackingKey = e.ID
+	}
+
+	if e.Tags == nil {
+		e.Tags = []string{}
+	}
+
+	e.Variations = []ExperimentVariation{}
+	for i, variation := range experiment.Spec.Variations {
+		key := variation.Key
+		if key == "" {
+			key = strconv.Itoa(i)
+		}
+
+		id := variation.ID
+		if id == "" {
+			id = generateVariationID(e.ID, key)
+		}
+
+		e.Variations = append(e.Variations, ExperimentVariation{
+			ID:          id,
+			Key:         key,
+			Name:        variation.Name,
+			Description: variation.Description,
+			Screenshots: bson.A{},
+		})
+	}
+
+	e.Phases = []ExperimentPhase{}
+	for _, phase := range experiment.Spec.Phases {
+		coverage := 1.0
+		if phase.Coverage != "" {
+			coverage, _ = strconv.ParseFloat(phase.Coverage, 64)
+		}
+
+		weights := []float64{}
+		for _, weight := range phase.VariationWeights {
+			w, _ := strconv.ParseFloat(weight, 64)
+			weights = append(weights, w)
+		}
+
+		if len(weights) == 0 {
+			for range e.Variations {
+				weights = append(weights, 1/float64(len(e.Variations)))
+			}
+		}
+
+		savedGroups := []SavedGroupTargeting{}
+		for _, savedGroup := range phase.SavedGroups {
+			savedGroups = append(savedGroups, SavedGroupTargeting{
+				Match: SavedGroupTargetingMatch(savedGroup.Match),
+				IDs:   savedGroup.IDs,
+			})
+		}
+
+		storePhase := ExperimentPhase{
+			Name:             phase.Name,
+			Reason:           phase.Reason,
+			Coverage:         coverage,
+			Condition:        phase.Condition,
+			VariationWeights: weights,
+			SavedGroups:      savedGroups,
+			Seed:             phase.Seed,
+		}
+
+		if phase.DateStarted != nil {
+			storePhase.DateStarted = phase.DateStarted.UTC()
+		}
+
+		if phase.DateEnded != nil {
+			dateEnded := phase.DateEnded.UTC()
+			storePhase.DateEnded = &dateEnded
+		}
+
+		if phase.Namespace != nil {
+			storePhase.Namespace = NamespaceValue{
+				Enabled: phase.Namespace.Enabled,
+				Name:    phase.Namespace.Name,
+			}
+
+			for _, v := range phase.Namespace.Range {
+				rangeNumber, _ := strconv.ParseFloat(v, 64)
+				storePhase.Namespace.Range = append(storePhase.Namespace.Range, rangeNumber)
+			}
+		}
+
+		e.Phases = append(e.Phases, storePhase)
+	}
+
+	return e
+}
+
+// generateVariationID returns a stable variation id derived from the experiment and the variation key
+func generateVariationID(experiment, key string) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s", experiment, key)))
+	return managedVariationIDPrefix + hex.EncodeToString(h[:])[:16]
+}
+
+func DeleteExperiment(ctx context.Context, experiment Experiment, db storage.Database) error {
+	col := db.Collection("experiments")
+	filter := bson.M{
+		"id":           experiment.ID,
+		"organization": experiment.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateExperiment(ctx context.Context, experiment Experiment, db storage.Database) error {
+	col := db.Collection("experiments")
+	filter := bson.M{
+		"id":           experiment.ID,
+		"organization": experiment.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, experiment, documentUpdate[Experiment]{
+		name:  fmt.Sprintf("experiment %s in organization %s", experiment.ID, experiment.Organization),
+		merge: mergeFunc(mergeExperiment),
+		create: func(experiment Experiment) (Experiment, error) {
+			experiment = mergeExperiment(Experiment{}, experiment)
+			experiment.DateCreated = time.Now()
+			experiment.DateUpdated = experiment.DateCreated
+			return experiment, nil
+		},
+	})
+
+	return err
+}
+
+func GetExperimentMeta(ctx context.Context, experiment Experiment, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("experiments"), bson.M{
+		"id":           experiment.ID,
+		"organization": experiment.Organization,
+	})
+}
+
+// FindExperiment returns the stored experiment and whether it exists in the organization
+func FindExperiment(ctx context.Context, experiment Experiment, db storage.Database) (Experiment, bool, error) {
+	var existing Experiment
+	result, err := db.Collection("experiments").FindOne(ctx, bson.M{
+		"id":           experiment.ID,
+		"organization": experiment.Organization,
+	})
+	if isNotFound(err) {
+		return existing, false, nil
+	}
+
+	if err != nil {
+		return existing, false, err
+	}
+
+	return existing, true, result.Decode(&existing)
+}
+
+func DiffExperiment(ctx context.Context, experiment Experiment, db storage.Database) ([]string, error) {
+	col := db.Collection("experiments")
+	filter := bson.M{
+		"id":           experiment.ID,
+		"organization": experiment.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, experiment, mergeFunc(mergeExperiment))
+}
+
+func ArchiveExperiment(ctx context.Context, experiment Experiment, db storage.Database) error {
+	col := db.Collection("experiments")
+	filter := bson.M{
+		"id":           experiment.ID,
+		"organization": experiment.Organization,
+	}
+
+	update := bson.D{
+		{Key: "$set", Value: bson.M{
+			"archived":    true,
+			"dateUpdated": time.Now(),
+		}},
+	}
+
+	err := col.UpdateOne(ctx, filter, update)
+	if errors.Is(err, storage.ErrNoMatch) {
+		return nil
+	}
+
+	return err
+}
+
+func mergeExperiment(existing, experiment Experiment) Experiment {
+	existing.ID = experiment.ID
+	existing.Organization = experiment.Organization
+	existing.Project = experiment.Project
+	existing.TrackingKey = experiment.TrackingKey
+	existing.Name = experiment.Name
+	existing.Owner = experiment.Owner
+	existing.Description = experiment.Description
+	existing.Hypothesis = experiment.Hypothesis
+	existing.Tags = experiment.Tags
+	existing.HashAttribute = experiment.HashAttribute
+	existing.FallbackAttribute = experiment.FallbackAttribute
+	existing.Status = experiment.Status
+	existing.Archived = experiment.Archived
+
+	// Screenshots are uploaded using the growthbook UI
+	variations := slices.Clone(experiment.Variations)
+	for i, variation := range variations {
+		for _, existingVariation := range existing.Variations {
+			if existingVariation.ID == variation.ID && existingVariation.Screenshots != nil {
+				variations[i].Screenshots = existingVariation.Screenshots
+			}
+		}
+	}
+
+	// Phases without a start date keep the start date from when they have been created
+	phases := slices.Clone(experiment.Phases)
+	for i, phase := range phases {
+		if !phase.DateStarted.IsZero() {
+			continue
+		}
+
+		if i < len(existing.Phases) {
+			phases[i].DateStarted = existing.Phases[i].DateStarted
+		} else {
+			phases[i].DateStarted = time.Now().UTC().Truncate(time.Second)
+		}
+	}
+
+	existing.Variations = variations
+	existing.Phases = phases
+
+	return existing
+}
@@ -0,0 +1,315 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestExperimentFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	dateStarted := metav1.NewTime(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	apiSpec := v1beta1.GrowthbookExperiment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookExperimentSpec{
+			Description:   "foo",
+			Hypothesis:    "bar",
+			HashAttribute: "id",
+			Status:        v1beta1.ExperimentStatusRunning,
+			Variations: []v1beta1.ExperimentVariation{
+				{
+					Name: "control",
+				},
+				{
+					ID:   "var_treatment",
+					Key:  "treatment",
+					Name: "treatment",
+				},
+			},
+			Phases: []v1beta1.ExperimentPhase{
+				{
+					DateStarted: &dateStarted,
+					Coverage:    "0.5",
+					Condition:   `{"country": "CH"}`,
+				},
+				{
+					VariationWeights: []string{"0.2", "0.8"},
+				},
+			},
+		},
+	}
+
+	e := &Experiment{}
+	e.FromV1beta1(apiSpec)
+	g.Expect(e.ID).To(Equal(apiSpec.Name))
+	g.Expect(e.Name).To(Equal(apiSpec.Name))
+	g.Expect(e.TrackingKey).To(Equal(apiSpec.Name))
+	g.Expect(e.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(e.Hypothesis).To(Equal(apiSpec.Spec.Hypothesis))
+	g.Expect(e.HashAttribute).To(Equal(apiSpec.Spec.HashAttribute))
+	g.Expect(e.Status).To(Equal(ExperimentStatusRunning))
+	g.Expect(e.Tags).To(Equal([]string{}))
+
+	g.Expect(e.Variations[0].ID).To(Equal(generateVariationID("foo", "0")))
+	g.Expect(e.Variations[0].ID).To(HavePrefix(managedVariationIDPrefix))
+	g.Expect(e.Variations[0].Key).To(Equal("0"))
+	g.Expect(e.Variations[1].ID).To(Equal("var_treatment"))
+	g.Expect(e.Variations[1].Key).To(Equal("treatment"))
+
+	g.Expect(e.Phases[0].DateStarted).To(Equal(dateStarted.UTC()))
+	g.Expect(e.Phases[0].Coverage).To(Equal(0.5))
+	g.Expect(e.Phases[0].Condition).To(Equal(`{"country": "CH"}`))
+	g.Expect(e.Phases[0].VariationWeights).To(Equal([]float64{0.5, 0.5}))
+	g.Expect(e.Phases[1].Coverage).To(Equal(1.0))
+	g.Expect(e.Phases[1].VariationWeights).To(Equal([]float64{0.2, 0.8}))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.TrackingKey = "key"
+	e.FromV1beta1(apiSpec)
+	g.Expect(e.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(e.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(e.TrackingKey).To(Equal(apiSpec.Spec.TrackingKey))
+}
+
+func TestMergeExperiment(t *testing.T) {
+	g := NewWithT(t)
+
+	dateStarted := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := Experiment{
+		ID: "id",
+		Variations: []ExperimentVariation{
+			{
+				ID:          "var_a",
+				Screenshots: bson.A{bson.D{{Key: "path", Value: "/a.png"}}},
+			},
+		},
+		Phases: []ExperimentPhase{
+			{
+				DateStarted: dateStarted,
+			},
+		},
+	}
+
+	experiment := Experiment{
+		ID: "id",
+		Variations: []ExperimentVariation{
+			{
+				ID:          "var_a",
+				Name:        "a",
+				Screenshots: bson.A{},
+			},
+			{
+				ID:          "var_b",
+				Screenshots: bson.A{},
+			},
+		},
+		Phases: []ExperimentPhase{
+			{},
+			{},
+		},
+	}
+
+	merged := mergeExperiment(existing, experiment)
+	g.Expect(merged.Variations[0].Name).To(Equal("a"))
+	g.Expect(merged.Variations[0].Screenshots).To(Equal(existing.Variations[0].Screenshots))
+	g.Expect(merged.Variations[1].Screenshots).To(Equal(bson.A{}))
+	g.Expect(merged.Phases[0].DateStarted).To(Equal(dateStarted))
+	g.Expect(merged.Phases[1].DateStarted).NotTo(BeZero())
+	g.Expect(experiment.Phases[1].DateStarted).To(BeZero())
+}
+
+func TestExperimentArchive(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter bson.M
+	var updateDoc bson.D
+	db := &MockDatabase{
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter.(bson.M)
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := ArchiveExperiment(context.TODO(), Experiment{ID: "experiment", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "experiment",
+		"organization": "org",
+	}))
+
+	set := updateDoc[0].Value.(bson.M)
+	g.Expect(set["archived"]).To(BeTrue())
+}
+
+func TestExperimentDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	experiment := Experiment{
+		ID:           "experiment",
+		Organization: "org",
+	}
+
+	err := DeleteExperiment(context.TODO(), experiment, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "experiment",
+		"organization": "org",
+	}))
+}
+
+func TestExperimentCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Experiment
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Experiment)
+			return nil
+		},
+	}
+
+	experiment := Experiment{
+		ID: "experiment",
+	}
+
+	err := UpdateExperiment(context.TODO(), experiment, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal(experiment.ID))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestExperimentNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Experiment).ID = "id"
+					dst.(*Experiment).Organization = "org"
+					dst.(*Experiment).Tags = []string{}
+					dst.(*Experiment).Variations = []ExperimentVariation{}
+					dst.(*Experiment).Phases = []ExperimentPhase{}
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	experiment := Experiment{
+		ID:           "id",
+		Organization: "org",
+		Tags:         []string{},
+		Variations:   []ExperimentVariation{},
+		Phases:       []ExperimentPhase{},
+	}
+
+	err := UpdateExperiment(context.TODO(), experiment, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestExperimentUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Experiment).ID = "id"
+					dst.(*Experiment).Organization = "org"
+					dst.(*Experiment).Description = "old"
+					dst.(*Experiment).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	experiment := Experiment{
+		ID:           "id",
+		Organization: "org",
+		Description:  "new",
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateExperiment(context.TODO(), experiment, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("description").StringValue()).To(Equal("new"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "id",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestExperimentFind(t *testing.T) {
+	g := NewWithT(t)
+
+	var findFilter interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			findFilter = filter
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Experiment).ID = "exp"
+					dst.(*Experiment).Variations = []ExperimentVariation{{ID: "var_a", Key: "a"}}
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	experiment, exists, err := FindExperiment(context.TODO(), Experiment{ID: "exp", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(exists).To(BeTrue())
+	g.Expect(experiment.Variations).To(Equal([]ExperimentVariation{{ID: "var_a", Key: "a"}}))
+	g.Expect(findFilter).To(Equal(bson.M{
+		"id":           "exp",
+		"organization": "org",
+	}))
+
+	db.FindOne = func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	_, exists, err = FindExperiment(context.TODO(), Experiment{ID: "exp", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(exists).To(BeFalse())
+}
@@ -0,0 +1,172 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FactMetric is a metric computed from the facts of fact tables
+type FactMetric struct {
+	ID              string                `bson:"id"`
+	Organization    string                `bson:"organization"`
+	Owner           string                `bson:"owner"`
+	DataSource      string                `bson:"datasource"`
+	Name            string                `bson:"name"`
+	Description     string                `bson:"description"`
+	Projects        []string              `bson:"projects"`
+	Tags            []string              `bson:"tags"`
+	MetricType      string                `bson:"metricType"`
+	Inverse         bool                  `bson:"inverse"`
+	Numerator       FactColumnRef         `bson:"numerator"`
+	Denominator     *FactColumnRef        `bson:"denominator"`
+	CappingSettings MetricCappingSettings `bson:"cappingSettings"`
+	WindowSettings  MetricWindowSettings  `bson:"windowSettings"`
+	DateCreated     time.Time             `bson:"dateCreated"`
+	DateUpdated     time.Time             `bson:"dateUpdated"`
+	Revision        int                   `bson:"__v"`
+}
+
+type FactColumnRef struct {
+	FactTableID string   `bson:"factTableId"`
+	Column      string   `bson:"column"`
+	Filters     []string `bson:"filters"`
+}
+
+func (m *FactMetric) FromV1beta1(factMetric v1beta1.GrowthbookFactMetric) *FactMetric {
+	m.ID = factMetric.GetID()
+	m.Name = factMetric.GetName()
+	m.Description = factMetric.Spec.Description
+	m.Owner = factMetric.Spec.Owner
+	m.Tags = append([]string{}, factMetric.Spec.Tags...)
+	m.MetricType = string(factMetric.Spec.MetricType)
+	m.Inverse = factMetric.Spec.Inverse
+
+	// The data source, the projects and the fact tables are referenced by resource name and need to be resolved by the caller
+	m.DataSource = factMetric.Spec.DataSource
+	m.Projects = append([]string{}, factMetric.Spec.Projects...)
+	m.Numerator = newFactColumnRef(factMetric.Spec.Numerator)
+	m.Denominator = nil
+
+	if factMetric.Spec.Denominator != nil {
+		denominator := newFactColumnRef(*factMetric.Spec.Denominator)
+		m.Denominator = &denominator
+	}
+
+	m.CappingSettings = MetricCappingSettings{}
+	if factMetric.Spec.Cap != nil {
+		value, _ := strconv.ParseFloat(factMetric.Spec.Cap.Value, 64)
+		m.CappingSettings = MetricCappingSettings{
+			Type:  factMetric.Spec.Cap.Type,
+			Value: value,
+		}
+	}
+
+	m.WindowSettings = MetricWindowSettings{
+		WindowValue: 72,
+		WindowUnit:  "hours",
+	}
+
+	if factMetric.Spec.Window != nil {
+		m.WindowSettings.Type = factMetric.Spec.Window.Type
+		m.WindowSettings.DelayHours = factMetric.Spec.Window.DelayHours
+
+		if m.WindowSettings.Type == "" {
+			m.WindowSettings.Type = "conversion"
+		}
+
+		if factMetric.Spec.Window.Value != 0 {
+			m.WindowSettings.WindowValue = factMetric.Spec.Window.Value
+		}
+
+		if factMetric.Spec.Window.Unit != "" {
+			m.WindowSettings.WindowUnit = factMetric.Spec.Window.Unit
+		}
+	}
+
+	return m
+}
+
+func newFactColumnRef(ref v1beta1.FactColumnReference) FactColumnRef {
+	column := ref.Column
+	if column == "" {
+		column = "$$count"
+	}
+
+	return FactColumnRef{
+		FactTableID: ref.FactTable,
+		Column:      column,
+		Filters:     append([]string{}, ref.Filters...),
+	}
+}
+
+func DeleteFactMetric(ctx context.Context, factMetric FactMetric, db storage.Database) error {
+	col := db.Collection("factmetrics")
+	filter := bson.M{
+		"id":           factMetric.ID,
+		"organization": factMetric.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateFactMetric(ctx context.Context, factMetric FactMetric, db storage.Database) error {
+	col := db.Collection("factmetrics")
+	filter := bson.M{
+		"id":           factMetric.ID,
+		"organization": factMetric.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, factMetric, documentUpdate[FactMetric]{
+		name:  fmt.Sprintf("fact metric %s in organization %s", factMetric.ID, factMetric.Organization),
+		merge: mergeFunc(mergeFactMetric),
+		create: func(factMetric FactMetric) (FactMetric, error) {
+			factMetric.DateCreated = time.Now()
+			factMetric.DateUpdated = factMetric.DateCreated
+			return factMetric, nil
+		},
+	})
+
+	return err
+}
+
+func GetFactMetricMeta(ctx context.Context, factMetric FactMetric, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("factmetrics"), bson.M{
+		"id":           factMetric.ID,
+		"organization": factMetric.Organization,
+	})
+}
+
+func DiffFactMetric(ctx context.Context, factMetric FactMetric, db storage.Database) ([]string, error) {
+	col := db.Collection("factmetrics")
+	filter := bson.M{
+		"id":           factMetric.ID,
+		"organization": factMetric.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, factMetric, mergeFunc(mergeFactMetric))
+}
+
+func mergeFactMetric(existing, factMetric FactMetric) FactMetric {
+	existing.ID = factMetric.ID
+	existing.Organization = factMetric.Organization
+	existing.Owner = factMetric.Owner
+	existing.DataSource = factMetric.DataSource
+	existing.Name = factMetric.Name
+	existing.Description = factMetric.Description
+	existing.Projects = factMetric.Projects
+	existing.Tags = factMetric.Tags
+	existing.MetricType = factMetric.MetricType
+	existing.Inverse = factMetric.Inverse
+	existing.Numerator = factMetric.Numerator
+	existing.Denominator = factMetric.Denominator
+	existing.CappingSettings = factMetric.CappingSettings
+	existing.WindowSettings = factMetric.WindowSettings
+
+	return existing
+}
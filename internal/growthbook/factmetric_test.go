@@ -0,0 +1,183 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFactMetricFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookFactMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookFactMetricSpec{
+			Description: "foo",
+			DataSource:  "warehouse",
+			MetricType:  v1beta1.FactMetricTypeRatio,
+			Numerator: v1beta1.FactColumnReference{
+				FactTable: "orders",
+				Column:    "amount",
+				Filters:   []string{"large"},
+			},
+			Denominator: &v1beta1.FactColumnReference{
+				FactTable: "orders",
+			},
+			Window: &v1beta1.MetricWindow{
+				Type:  "lookback",
+				Value: 14,
+				Unit:  "days",
+			},
+		},
+	}
+
+	m := &FactMetric{}
+	m.FromV1beta1(apiSpec)
+	g.Expect(m.ID).To(Equal(apiSpec.Name))
+	g.Expect(m.Name).To(Equal(apiSpec.Name))
+	g.Expect(m.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(m.DataSource).To(Equal("warehouse"))
+	g.Expect(m.MetricType).To(Equal("ratio"))
+	g.Expect(m.Numerator).To(Equal(FactColumnRef{FactTableID: "orders", Column: "amount", Filters: []string{"large"}}))
+	g.Expect(m.Denominator).To(Equal(&FactColumnRef{FactTableID: "orders", Column: "$$count", Filters: []string{}}))
+	g.Expect(m.WindowSettings).To(Equal(MetricWindowSettings{Type: "lookback", WindowValue: 14, WindowUnit: "days"}))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Denominator = nil
+	m.FromV1beta1(apiSpec)
+	g.Expect(m.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(m.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(m.Denominator).To(BeNil())
+}
+
+func TestFactMetricDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteFactMetric(context.TODO(), FactMetric{ID: "factmetric", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "factmetric",
+		"organization": "org",
+	}))
+}
+
+func TestFactMetricCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc FactMetric
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(FactMetric)
+			return nil
+		},
+	}
+
+	err := UpdateFactMetric(context.TODO(), FactMetric{ID: "factmetric", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("factmetric"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestFactMetricNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*FactMetric).ID = "factmetric"
+					dst.(*FactMetric).Organization = "org"
+					dst.(*FactMetric).MetricType = "mean"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateFactMetric(context.TODO(), FactMetric{ID: "factmetric", Organization: "org", MetricType: "mean"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestFactMetricUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*FactMetric).ID = "factmetric"
+					dst.(*FactMetric).Organization = "org"
+					dst.(*FactMetric).MetricType = "proportion"
+					dst.(*FactMetric).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateFactMetric(context.TODO(), FactMetric{ID: "factmetric", Organization: "org", MetricType: "mean"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("metricType").StringValue()).To(Equal("mean"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "factmetric",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestFactMetricDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*FactMetric).ID = "factmetric"
+					dst.(*FactMetric).Organization = "org"
+					dst.(*FactMetric).MetricType = "proportion"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffFactMetric(context.TODO(), FactMetric{ID: "factmetric", Organization: "org", MetricType: "mean"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"metricType"}))
+}
@@ -0,0 +1,215 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// FactTable is a SQL query returning facts which fact metrics are computed from
+type FactTable struct {
+	ID           string            `bson:"id"`
+	Organization string            `bson:"organization"`
+	Owner        string            `bson:"owner"`
+	DataSource   string            `bson:"datasource"`
+	Name         string            `bson:"name"`
+	Description  string            `bson:"description"`
+	Projects     []string          `bson:"projects"`
+	Tags         []string          `bson:"tags"`
+	UserIDTypes  []string          `bson:"userIdTypes"`
+	SQL          string            `bson:"sql"`
+	EventName    string            `bson:"eventName"`
+	Columns      []FactTableColumn `bson:"columns"`
+	Filters      []FactTableFilter `bson:"filters"`
+	DateCreated  time.Time         `bson:"dateCreated"`
+	DateUpdated  time.Time         `bson:"dateUpdated"`
+	Revision     int               `bson:"__v"`
+}
+
+type FactTableColumn struct {
+	Column       string    `bson:"column"`
+	Name         string    `bson:"name"`
+	Datatype     string    `bson:"datatype"`
+	NumberFormat string    `bson:"numberFormat"`
+	Deleted      bool      `bson:"deleted"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	DateUpdated  time.Time `bson:"dateUpdated"`
+}
+
+type FactTableFilter struct {
+	ID          string    `bson:"id"`
+	Name        string    `bson:"name"`
+	Description string    `bson:"description"`
+	Value       string    `bson:"value"`
+	DateCreated time.Time `bson:"dateCreated"`
+	DateUpdated time.Time `bson:"dateUpdated"`
+}
+
+func (t *FactTable) FromV1beta1(factTable v1beta1.GrowthbookFactTable) *FactTable {
+	t.ID = factTable.GetID()
+	t.Name = factTable.GetName()
+	t.Description = factTable.Spec.Description
+	t.Owner = factTable.Spec.Owner
+	t.Tags = append([]string{}, factTable.Spec.Tags...)
+	t.UserIDTypes = append([]string{}, factTable.Spec.UserIDTypes...)
+	t.SQL = factTable.Spec.SQL
+	t.EventName = factTable.Spec.EventName
+
+	// The data source and the projects are referenced by resource name and need to be resolved by the caller
+	t.DataSource = factTable.Spec.DataSource
+	t.Projects = append([]string{}, factTable.Spec.Projects...)
+
+	if factTable.Spec.Columns != nil {
+		t.Columns = []FactTableColumn{}
+	}
+
+	for _, column := range factTable.Spec.Columns {
+		name := column.Name
+		if name == "" {
+			name = column.Column
+		}
+
+		datatype := column.Datatype
+		if datatype == "" {
+			datatype = "string"
+		}
+
+		t.Columns = append(t.Columns, FactTableColumn{
+			Column:       column.Column,
+			Name:         name,
+			Datatype:     datatype,
+			NumberFormat: column.NumberFormat,
+		})
+	}
+
+	t.Filters = []FactTableFilter{}
+	for _, filter := range factTable.Spec.Filters {
+		name := filter.Name
+		if name == "" {
+			name = filter.ID
+		}
+
+		t.Filters = append(t.Filters, FactTableFilter{
+			ID:          filter.ID,
+			Name:        name,
+			Description: filter.Description,
+			Value:       filter.Value,
+		})
+	}
+
+	return t
+}
+
+func DeleteFactTable(ctx context.Context, factTable FactTable, db storage.Database) error {
+	col := db.Collection("facttables")
+	filter := bson.M{
+		"id":           factTable.ID,
+		"organization": factTable.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateFactTable(ctx context.Context, factTable FactTable, db storage.Database) error {
+	col := db.Collection("facttables")
+	filter := bson.M{
+		"id":           factTable.ID,
+		"organization": factTable.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, factTable, documentUpdate[FactTable]{
+		name:  fmt.Sprintf("fact table %s in organization %s", factTable.ID, factTable.Organization),
+		merge: mergeFunc(mergeFactTable),
+		create: func(factTable FactTable) (FactTable, error) {
+			factTable = mergeFactTable(FactTable{}, factTable)
+			factTable.DateCreated = time.Now()
+			factTable.DateUpdated = factTable.DateCreated
+			return factTable, nil
+		},
+	})
+
+	return err
+}
+
+func GetFactTableMeta(ctx context.Context, factTable FactTable, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("facttables"), bson.M{
+		"id":           factTable.ID,
+		"organization": factTable.Organization,
+	})
+}
+
+func DiffFactTable(ctx context.Context, factTable FactTable, db storage.Database) ([]string, error) {
+	col := db.Collection("facttables")
+	filter := bson.M{
+		"id":           factTable.ID,
+		"organization": factTable.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, factTable, mergeFunc(mergeFactTable))
+}
+
+func mergeFactTable(existing, factTable FactTable) FactTable {
+	existing.ID = factTable.ID
+	existing.Organization = factTable.Organization
+	existing.Owner = factTable.Owner
+	existing.DataSource = factTable.DataSource
+	existing.Name = factTable.Name
+	existing.Description = factTable.Description
+	existing.Projects = factTable.Projects
+	existing.Tags = factTable.Tags
+	existing.UserIDTypes = factTable.UserIDTypes
+	existing.SQL = factTable.SQL
+	existing.EventName = factTable.EventName
+
+	// Columns are detected by growthbook, they are only replaced if declared
+	if factTable.Columns != nil {
+		existing.Columns = mergeFactTableColumns(existing.Columns, factTable.Columns)
+	}
+
+	existing.Filters = mergeFactTableFilters(existing.Filters, factTable.Filters)
+	return existing
+}
+
+// mergeFactTableColumns retains the dates of existing columns
+func mergeFactTableColumns(existing, columns []FactTableColumn) []FactTableColumn {
+	merged := []FactTableColumn{}
+	for _, column := range columns {
+		column.DateCreated = time.Now()
+		column.DateUpdated = column.DateCreated
+
+		for _, existingColumn := range existing {
+			if existingColumn.Column == column.Column {
+				column.DateCreated = existingColumn.DateCreated
+				column.DateUpdated = existingColumn.DateUpdated
+			}
+		}
+
+		merged = append(merged, column)
+	}
+
+	return merged
+}
+
+// mergeFactTableFilters retains the dates of existing filters
+func mergeFactTableFilters(existing, filters []FactTableFilter) []FactTableFilter {
+	merged := []FactTableFilter{}
+	for _, filter := range filters {
+		filter.DateCreated = time.Now()
+		filter.DateUpdated = filter.DateCreated
+
+		for _, existingFilter := range existing {
+			if existingFilter.ID == filter.ID {
+				filter.DateCreated = existingFilter.DateCreated
+				filter.DateUpdated = existingFilter.DateUpdated
+			}
+		}
+
+		merged = append(merged, filter)
+	}
+
+	return merged
+}
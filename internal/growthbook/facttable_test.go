@@ -0,0 +1,218 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestFactTableFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookFactTable{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookFactTableSpec{
+			Description: "foo",
+			DataSource:  "warehouse",
+			UserIDTypes: []string{"user_id"},
+			SQL:         "SELECT user_id, timestamp, amount FROM orders",
+			Columns: []v1beta1.FactTableColumn{
+				{Column: "amount", Datatype: "number", NumberFormat: "currency"},
+			},
+			Filters: []v1beta1.FactTableFilter{
+				{ID: "large", Value: "amount > 100"},
+			},
+		},
+	}
+
+	ft := &FactTable{}
+	ft.FromV1beta1(apiSpec)
+	g.Expect(ft.ID).To(Equal(apiSpec.Name))
+	g.Expect(ft.Name).To(Equal(apiSpec.Name))
+	g.Expect(ft.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(ft.DataSource).To(Equal("warehouse"))
+	g.Expect(ft.SQL).To(Equal(apiSpec.Spec.SQL))
+	g.Expect(ft.Columns).To(Equal([]FactTableColumn{
+		{Column: "amount", Name: "amount", Datatype: "number", NumberFormat: "currency"},
+	}))
+	g.Expect(ft.Filters).To(Equal([]FactTableFilter{
+		{ID: "large", Name: "large", Value: "amount > 100"},
+	}))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Columns = nil
+	ft = &FactTable{}
+	ft.FromV1beta1(apiSpec)
+	g.Expect(ft.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(ft.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(ft.Columns).To(BeNil())
+}
+
+func TestFactTableDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteFactTable(context.TODO(), FactTable{ID: "facttable", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "facttable",
+		"organization": "org",
+	}))
+}
+
+func TestFactTableCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc FactTable
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(FactTable)
+			return nil
+		},
+	}
+
+	err := UpdateFactTable(context.TODO(), FactTable{ID: "facttable", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("facttable"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestFactTableNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*FactTable).ID = "facttable"
+					dst.(*FactTable).Organization = "org"
+					dst.(*FactTable).SQL = "SELECT * FROM purchases"
+					dst.(*FactTable).Filters = []FactTableFilter{}
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateFactTable(context.TODO(), FactTable{ID: "facttable", Organization: "org", SQL: "SELECT * FROM purchases"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestFactTableUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*FactTable).ID = "facttable"
+					dst.(*FactTable).Organization = "org"
+					dst.(*FactTable).SQL = "SELECT * FROM orders"
+					dst.(*FactTable).Filters = []FactTableFilter{}
+					dst.(*FactTable).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateFactTable(context.TODO(), FactTable{ID: "facttable", Organization: "org", SQL: "SELECT * FROM purchases"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("sql").StringValue()).To(Equal("SELECT * FROM purchases"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "facttable",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestFactTableDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*FactTable).ID = "facttable"
+					dst.(*FactTable).Organization = "org"
+					dst.(*FactTable).SQL = "SELECT * FROM orders"
+					dst.(*FactTable).Filters = []FactTableFilter{}
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffFactTable(context.TODO(), FactTable{ID: "facttable", Organization: "org", SQL: "SELECT * FROM purchases"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"sql"}))
+}
+
+func TestFactTableMergeRetainsColumnDates(t *testing.T) {
+	g := NewWithT(t)
+
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	existing := FactTable{
+		Columns: []FactTableColumn{
+			{Column: "amount", Datatype: "string", DateCreated: created, DateUpdated: created},
+		},
+		Filters: []FactTableFilter{
+			{ID: "large", Value: "amount > 100", DateCreated: created, DateUpdated: created},
+		},
+	}
+
+	merged := mergeFactTable(existing, FactTable{
+		Columns: []FactTableColumn{
+			{Column: "amount", Datatype: "number"},
+			{Column: "country", Datatype: "string"},
+		},
+		Filters: []FactTableFilter{
+			{ID: "large", Value: "amount > 1000"},
+		},
+	})
+
+	g.Expect(merged.Columns[0].Datatype).To(Equal("number"))
+	g.Expect(merged.Columns[0].DateCreated).To(Equal(created))
+	g.Expect(merged.Columns[1].DateCreated).NotTo(Equal(created))
+	g.Expect(merged.Filters[0].Value).To(Equal("amount > 1000"))
+	g.Expect(merged.Filters[0].DateCreated).To(Equal(created))
+
+	merged = mergeFactTable(existing, FactTable{})
+	g.Expect(merged.Columns).To(Equal(existing.Columns))
+	g.Expect(merged.Filters).To(Equal([]FactTableFilter{}))
+}
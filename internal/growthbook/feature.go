@@ -3,12 +3,19 @@ package growthbook
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
 	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
 	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
 )
 
 type FeatureValueType string
@@ -27,6 +34,7 @@ type Feature struct {
 	Tags                []string                      `bson:"tags"`
 	DefaultValue        string                        `bson:"defaultValue"`
 	ValueType           FeatureValueType              `bson:"valueType"`
+	Project             string                        `bson:"project"`
 	Organization        string                        `bson:"organization"`
 	Environments        []string                      `bson:"environment"`
 	EnvironmentSettings map[string]EnvironmentSetting `bson:"environmentSettings"`
@@ -34,8 +42,23 @@ type Feature struct {
 	DateUpdated         time.Time                     `bson:"dateUpdated"`
 	Archived            bool                          `bson:"archived"`
 	Revision            int                           `bson:"__v"`
+	RuleMergeStrategy   RuleMergeStrategy             `bson:"-"`
+	// ManagedRuleIDs are the explicit ids of rules previously applied from the spec.
+	// Existing rules with these ids are removed unless they are still part of the spec.
+	ManagedRuleIDs []string `bson:"-"`
 }
 
+type RuleMergeStrategy string
+
+var (
+	RuleMergeStrategyReplace RuleMergeStrategy = "Replace"
+	RuleMergeStrategyAppend  RuleMergeStrategy = "Append"
+	RuleMergeStrategyPrepend RuleMergeStrategy = "Prepend"
+)
+
+// managedRuleIDPrefix is the prefix of rule ids generated by the controller
+const managedRuleIDPrefix = "fr_k8s_"
+
 type EnvironmentSetting struct {
 	Enabled bool          `bson:"enabled"`
 	Rules   []FeatureRule `bson:"rules"`
@@ -120,6 +143,8 @@ func (f *Feature) FromV1beta1(feature v1beta1.GrowthbookFeature) *Feature {
 	f.Tags = feature.Spec.Tags
 	f.DefaultValue = feature.Spec.DefaultValue
 	f.ValueType = FeatureValueType(feature.Spec.ValueType)
+	f.RuleMergeStrategy = RuleMergeStrategy(feature.Spec.RuleMergeStrategy)
+	f.ManagedRuleIDs = feature.Status.ManagedRules
 
 	if f.Environments == nil {
 		f.Environments = []string{}
@@ -132,7 +157,12 @@ func (f *Feature) FromV1beta1(feature v1beta1.GrowthbookFeature) *Feature {
 	f.EnvironmentSettings = make(map[string]EnvironmentSetting)
 	for _, env := range feature.Spec.Environments {
 		var rules []FeatureRule
-		for _, rule := range env.Rules {
+		for i, rule := range env.Rules {
+			ruleID := rule.ID
+			if ruleID == "" {
+				ruleID = generateRuleID(f.ID, env.Name, i)
+			}
+
 			var scheduleRules []ScheduleRule
 			for _, scheduleRule := range rule.ScheduleRules {
 				scheduleRules = append(scheduleRules, ScheduleRule{
@@ -158,15 +188,29 @@ func (f *Feature) FromV1beta1(feature v1beta1.GrowthbookFeature) *Feature {
 			}
 
 			var experimentValues []ExperimentValue
-			for _, experimentValue := range rule.Values {
+			for j, experimentValue := range rule.Values {
+				valueID := experimentValue.ID
+				if valueID == "" {
+					valueID = fmt.Sprintf("%s_%d", ruleID, j)
+				}
+
 				weight, _ := strconv.ParseFloat(experimentValue.Weight, 64)
 				experimentValues = append(experimentValues, ExperimentValue{
+					ID:     valueID,
 					Value:  experimentValue.Value,
 					Weight: weight,
 					Name:   experimentValue.Name,
 				})
 			}
 
+			var variations []ExperimentRefVariation
+			for _, variation := range rule.Variations {
+				variations = append(variations, ExperimentRefVariation{
+					VariationId: variation.VariationId,
+					Value:       variation.Value,
+				})
+			}
+
 			coverage, _ := strconv.ParseFloat(rule.Coverage, 64)
 			var bucketVersion *float64
 			if rule.BucketVersion != nil {
@@ -180,6 +224,7 @@ func (f *Feature) FromV1beta1(feature v1beta1.GrowthbookFeature) *Feature {
 				minBucketVersion = &v
 			}
 			storeRule := FeatureRule{
+				ID:                     ruleID,
 				Type:                   FeatureRuleType(rule.Type),
 				Description:            rule.Description,
 				Condition:              rule.Condition,
@@ -196,6 +241,8 @@ func (f *Feature) FromV1beta1(feature v1beta1.GrowthbookFeature) *Feature {
 				BucketVersion:          bucketVersion,
 				MinBucketVersion:       minBucketVersion,
 				Values:                 experimentValues,
+				ExperimentID:           rule.ExperimentID,
+				Variations:             variations,
 			}
 
 			if rule.Namespace != nil {
@@ -229,45 +276,159 @@ func (f *Feature) FromV1beta1(feature v1beta1.GrowthbookFeature) *Feature {
 	return f
 }
 
-func DeleteFeature(ctx context.Context, feature Feature, db storage.Database) error {
+func DeleteFeature(ctx context.Context, feature Feature, db storage.Database, changes *ChangeSet) error {
 	col := db.Collection("features")
 	filter := bson.M{
-		"id": feature.ID,
+		"id":           feature.ID,
+		"organization": feature.Organization,
+	}
+
+	existing, exists := findFeature(ctx, col, filter)
+	if err := col.DeleteOne(ctx, filter); err != nil {
+		return err
+	}
+
+	if exists {
+		envs := touchedEnvironments(Feature{}, existing)
+		changes.Invalidate(feature.Organization, envs...)
+		changes.FeatureChanged(FeatureChange{
+			Organization: feature.Organization,
+			ID:           feature.ID,
+			Event:        FeatureEventDeleted,
+			Feature:      existing,
+			Environments: envs,
+		})
 	}
 
-	return col.DeleteOne(ctx, filter)
+	return nil
 }
 
-func UpdateFeature(ctx context.Context, feature Feature, db storage.Database) error {
+func ArchiveFeature(ctx context.Context, feature Feature, db storage.Database, changes *ChangeSet) error {
 	col := db.Collection("features")
 	filter := bson.M{
-		"id": feature.ID,
+		"id":           feature.ID,
+		"organization": feature.Organization,
 	}
 
-	var existing Feature
-	result, err := col.FindOne(ctx, filter)
+	now := time.Now()
+	update := bson.D{
+		{Key: "$set", Value: bson.M{
+			"archived":    true,
+			"dateUpdated": now,
+		}},
+	}
 
-	if err != nil {
-		feature.DateCreated = time.Now()
-		feature.DateUpdated = feature.DateCreated
-		return col.InsertOne(ctx, feature)
+	existing, exists := findFeature(ctx, col, filter)
+	err := col.UpdateOne(ctx, filter, update)
+	if errors.Is(err, storage.ErrNoMatch) {
+		return nil
 	}
 
-	if err := result.Decode(&existing); err != nil {
+	if err != nil {
 		return err
 	}
 
-	existingBson, err := bson.Marshal(existing)
+	if !exists {
+		return nil
+	}
+
+	archived := existing
+	archived.Archived = true
+	archived.DateUpdated = now
+
+	envs := touchedEnvironments(Feature{}, existing)
+	changes.Invalidate(feature.Organization, envs...)
+	changes.FeatureChanged(FeatureChange{
+		Organization: feature.Organization,
+		ID:           feature.ID,
+		Event:        FeatureEventUpdated,
+		Feature:      archived,
+		Previous:     &existing,
+		Environments: envs,
+	})
+
+	return nil
+}
+
+func UpdateFeature(ctx context.Context, feature Feature, db storage.Database, changes *ChangeSet) error {
+	col := db.Collection("features")
+	filter := bson.M{
+		"id":           feature.ID,
+		"organization": feature.Organization,
+	}
+
+	result, err := updateDocument(ctx, col, filter, feature, documentUpdate[Feature]{
+		name:  fmt.Sprintf("feature %s in organization %s", feature.ID, feature.Organization),
+		merge: mergeFunc(mergeFeature),
+		create: func(feature Feature) (Feature, error) {
+			feature.DateCreated = time.Now()
+			feature.DateUpdated = feature.DateCreated
+			return feature, nil
+		},
+	})
 	if err != nil {
 		return err
 	}
 
+	switch {
+	case result.created:
+		envs := touchedEnvironments(Feature{}, result.document)
+		changes.Invalidate(feature.Organization, envs...)
+		changes.FeatureChanged(FeatureChange{
+			Organization: feature.Organization,
+			ID:           feature.ID,
+			Event:        FeatureEventCreated,
+			Feature:      result.document,
+			Environments: envs,
+		})
+	case result.updated:
+		envs := touchedEnvironments(result.previous, result.document)
+
+		// Nothing is notified if only fields maintained by growthbook have been written
+		if len(envs) == 0 && !featureFieldsChanged(result.previous, result.document) {
+			return nil
+		}
+
+		changes.Invalidate(feature.Organization, envs...)
+		changes.FeatureChanged(FeatureChange{
+			Organization: feature.Organization,
+			ID:           feature.ID,
+			Event:        FeatureEventUpdated,
+			Feature:      result.document,
+			Previous:     &result.previous,
+			Environments: envs,
+		})
+	}
+
+	return nil
+}
+
+func GetFeatureMeta(ctx context.Context, feature Feature, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("features"), bson.M{
+		"id":           feature.ID,
+		"organization": feature.Organization,
+	})
+}
+
+func DiffFeature(ctx context.Context, feature Feature, db storage.Database) ([]string, error) {
+	col := db.Collection("features")
+	filter := bson.M{
+		"id":           feature.ID,
+		"organization": feature.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, feature, mergeFunc(mergeFeature))
+}
+
+func mergeFeature(existing, feature Feature) Feature {
 	existing.ID = feature.ID
 	existing.Description = feature.Description
 	existing.DefaultValue = feature.DefaultValue
 	existing.ValueType = feature.ValueType
+	existing.Project = feature.Project
 	existing.Tags = feature.Tags
 	existing.Environments = feature.Environments
+	existing.Archived = feature.Archived
 
 	if existing.EnvironmentSettings == nil {
 		existing.EnvironmentSettings = make(map[string]EnvironmentSetting)
@@ -277,7 +438,7 @@ func UpdateFeature(ctx context.Context, feature Feature, db storage.Database) er
 		if existingSettings, ok := existing.EnvironmentSettings[env]; ok {
 			s := existing.EnvironmentSettings[env]
 			s.Enabled = settings.Enabled
-			s.Rules = mergeRules(existingSettings.Rules, settings.Rules)
+			s.Rules = mergeRules(existingSettings.Rules, settings.Rules, feature.ManagedRuleIDs, feature.RuleMergeStrategy)
 			existing.EnvironmentSettings[env] = s
 		} else {
 			existing.EnvironmentSettings[env] = EnvironmentSetting{
@@ -293,35 +454,119 @@ func UpdateFeature(ctx context.Context, feature Feature, db storage.Database) er
 		}
 	}
 
-	updateBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
+	return existing
+}
+
+// mergeRules combines the rules from the spec with the rules created in the growthbook UI.
+// Rules without an id, with a generated id, with a managed id or with an id used by the spec are owned by the controller.
+func mergeRules(existing, spec []FeatureRule, managed []string, strategy RuleMergeStrategy) []FeatureRule {
+	if strategy == RuleMergeStrategyReplace {
+		return spec
 	}
 
-	if bytes.Equal(existingBson, updateBson) {
-		return nil
+	var rules []FeatureRule
+	for _, rule := range existing {
+		if rule.ID == "" || strings.HasPrefix(rule.ID, managedRuleIDPrefix) || slices.Contains(managed, rule.ID) {
+			continue
+		}
+
+		if slices.ContainsFunc(spec, func(r FeatureRule) bool {
+			return r.ID == rule.ID
+		}) {
+			continue
+		}
+
+		rules = append(rules, rule)
 	}
 
-	existing.DateUpdated = time.Now()
-	updateBson, err = bson.Marshal(existing)
+	if strategy == RuleMergeStrategyPrepend {
+		return append(slices.Clone(spec), rules...)
+	}
+
+	return append(rules, spec...)
+}
+
+// ExplicitRuleIDs returns the ids of all rules which have an explicit id in the spec
+func ExplicitRuleIDs(feature v1beta1.GrowthbookFeature) []string {
+	var ids []string
+	for _, env := range feature.Spec.Environments {
+		for _, rule := range env.Rules {
+			if rule.ID != "" && !slices.Contains(ids, rule.ID) {
+				ids = append(ids, rule.ID)
+			}
+		}
+	}
+
+	sort.Strings(ids)
+	return ids
+}
+
+// findFeature returns the stored feature matching the filter and whether it exists
+func findFeature(ctx context.Context, col storage.Collection, filter bson.M) (Feature, bool) {
+	var existing Feature
+	result, err := col.FindOne(ctx, filter)
 	if err != nil {
-		return err
+		return existing, false
 	}
 
-	update := bson.D{
-		{Key: "$set", Value: bson.Raw(updateBson)},
+	if err := result.Decode(&existing); err != nil {
+		return existing, false
 	}
 
-	return col.UpdateOne(ctx, filter, update)
+	return existing, true
 }
 
-func mergeRules(existing, spec []FeatureRule) []FeatureRule {
-	var rules []FeatureRule
-	for _, rule := range existing {
-		if rule.ID != "" {
-			rules = append(rules, rule)
+// featureFieldsChanged returns true if the feature itself differs, environment settings and metadata maintained by growthbook are ignored
+func featureFieldsChanged(from, to Feature) bool {
+	withoutSettings := func(f Feature) []byte {
+		f.EnvironmentSettings = nil
+		f.DateCreated = time.Time{}
+		f.DateUpdated = time.Time{}
+		f.Revision = 0
+		b, _ := MarshalCanonical(f)
+		return b
+	}
+
+	return !bytes.Equal(withoutSettings(from), withoutSettings(to))
+}
+
+// touchedEnvironments returns the environments affected by a change from one feature version to another.
+// A change of the feature itself affects all environments while changed environment settings only affect their environment.
+func touchedEnvironments(from, to Feature) []string {
+	var envs []string
+	for env := range from.EnvironmentSettings {
+		envs = append(envs, env)
+	}
+
+	for env := range to.EnvironmentSettings {
+		if _, ok := from.EnvironmentSettings[env]; !ok {
+			envs = append(envs, env)
 		}
 	}
 
-	return append(rules, spec...)
+	sort.Strings(envs)
+
+	if featureFieldsChanged(from, to) {
+		return envs
+	}
+
+	var touched []string
+	for _, env := range envs {
+		fromSettings, fromOK := from.EnvironmentSettings[env]
+		toSettings, toOK := to.EnvironmentSettings[env]
+		a, _ := MarshalCanonical(fromSettings)
+		b, _ := MarshalCanonical(toSettings)
+
+		if fromOK != toOK || !bytes.Equal(a, b) {
+			touched = append(touched, env)
+		}
+	}
+
+	return touched
+}
+
+// generateRuleID returns a stable rule id derived from the feature, the environment and the position of the rule
+func generateRuleID(feature, env string, index int) string {
+	h := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", feature, env, index)))
+	return managedRuleIDPrefix + hex.EncodeToString(h[:])[:16]
 }
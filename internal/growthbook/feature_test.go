@@ -3,6 +3,7 @@ package growthbook
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -59,13 +61,150 @@ func TestFeatureFromV1beta1(t *testing.T) {
 	g.Expect(f.EnvironmentSettings["production"].Enabled).To(BeTrue())
 	g.Expect(f.EnvironmentSettings["dev"].Enabled).To(BeFalse())
 	g.Expect(f.EnvironmentSettings["other"].Enabled).To(BeTrue())
-	g.Expect(f.EnvironmentSettings["other"].Rules).To(Equal([]FeatureRule{{Type: FeatureRuleTypeForce, Value: "false"}}))
+	g.Expect(f.EnvironmentSettings["other"].Rules).To(Equal([]FeatureRule{{ID: generateRuleID("foo", "other", 0), Type: FeatureRuleTypeForce, Value: "false"}}))
 
 	apiSpec.Spec.ID = "custom"
 	f.FromV1beta1(apiSpec)
 	g.Expect(f.ID).To(Equal(apiSpec.Spec.ID))
 }
 
+func TestFeatureFromV1beta1RuleIDs(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookFeature{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookFeatureSpec{
+			Environments: []v1beta1.Environment{
+				{
+					Name: "dev",
+					Rules: []v1beta1.FeatureRule{
+						{
+							ID:   "fr_explicit",
+							Type: v1beta1.FeatureRuleTypeForce,
+						},
+						{
+							Type: v1beta1.FeatureRuleTypeExperiment,
+							Values: []v1beta1.ExperimentValue{
+								{
+									Value: "a",
+								},
+								{
+									ID:    "var_b",
+									Value: "b",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	f := &Feature{}
+	f.FromV1beta1(apiSpec)
+	rules := f.EnvironmentSettings["dev"].Rules
+	g.Expect(rules[0].ID).To(Equal("fr_explicit"))
+	g.Expect(rules[1].ID).To(Equal(generateRuleID("foo", "dev", 1)))
+	g.Expect(rules[1].ID).To(HavePrefix(managedRuleIDPrefix))
+	g.Expect(rules[1].Values[0].ID).To(Equal(rules[1].ID + "_0"))
+	g.Expect(rules[1].Values[1].ID).To(Equal("var_b"))
+
+	// IDs are stable across conversions
+	again := &Feature{}
+	again.FromV1beta1(apiSpec)
+	g.Expect(again.EnvironmentSettings["dev"].Rules).To(Equal(rules))
+}
+
+func TestMergeRules(t *testing.T) {
+	g := NewWithT(t)
+
+	existing := []FeatureRule{
+		{ID: "ui_rule"},
+		{ID: managedRuleIDPrefix + "removed"},
+		{ID: "fr_explicit", Value: "old"},
+		{Value: "legacy"},
+	}
+
+	spec := []FeatureRule{
+		{ID: "fr_explicit", Value: "new"},
+		{ID: managedRuleIDPrefix + "a"},
+	}
+
+	g.Expect(mergeRules(existing, spec, nil, RuleMergeStrategyAppend)).To(Equal([]FeatureRule{
+		{ID: "ui_rule"},
+		{ID: "fr_explicit", Value: "new"},
+		{ID: managedRuleIDPrefix + "a"},
+	}))
+
+	g.Expect(mergeRules(existing, spec, nil, "")).To(Equal(mergeRules(existing, spec, nil, RuleMergeStrategyAppend)))
+
+	g.Expect(mergeRules(existing, spec, nil, RuleMergeStrategyPrepend)).To(Equal([]FeatureRule{
+		{ID: "fr_explicit", Value: "new"},
+		{ID: managedRuleIDPrefix + "a"},
+		{ID: "ui_rule"},
+	}))
+
+	g.Expect(mergeRules(existing, spec, nil, RuleMergeStrategyReplace)).To(Equal(spec))
+}
+
+func TestMergeRulesRemovedFromSpec(t *testing.T) {
+	g := NewWithT(t)
+
+	existing := []FeatureRule{
+		{ID: "ui_rule"},
+		{ID: "fr_removed", Value: "removed"},
+		{ID: "fr_explicit", Value: "old"},
+	}
+
+	spec := []FeatureRule{
+		{ID: "fr_explicit", Value: "new"},
+	}
+
+	managed := []string{"fr_explicit", "fr_removed"}
+
+	g.Expect(mergeRules(existing, spec, managed, RuleMergeStrategyAppend)).To(Equal([]FeatureRule{
+		{ID: "ui_rule"},
+		{ID: "fr_explicit", Value: "new"},
+	}))
+
+	g.Expect(mergeRules(existing, spec, managed, RuleMergeStrategyPrepend)).To(Equal([]FeatureRule{
+		{ID: "fr_explicit", Value: "new"},
+		{ID: "ui_rule"},
+	}))
+
+	// Without the managed ids the removed rule can not be told apart from a rule created in the UI
+	g.Expect(mergeRules(existing, spec, nil, RuleMergeStrategyAppend)).To(Equal([]FeatureRule{
+		{ID: "ui_rule"},
+		{ID: "fr_removed", Value: "removed"},
+		{ID: "fr_explicit", Value: "new"},
+	}))
+}
+
+func TestExplicitRuleIDs(t *testing.T) {
+	g := NewWithT(t)
+
+	feature := v1beta1.GrowthbookFeature{
+		Spec: v1beta1.GrowthbookFeatureSpec{
+			Environments: []v1beta1.Environment{
+				{Name: "dev", Rules: []v1beta1.FeatureRule{{ID: "fr_b"}, {}, {ID: "fr_a"}}},
+				{Name: "production", Rules: []v1beta1.FeatureRule{{ID: "fr_a"}}},
+			},
+		},
+		Status: v1beta1.GrowthbookFeatureStatus{
+			ManagedRules: []string{"fr_removed"},
+		},
+	}
+
+	g.Expect(ExplicitRuleIDs(feature)).To(Equal([]string{"fr_a", "fr_b"}))
+
+	f := &Feature{}
+	f.FromV1beta1(feature)
+	g.Expect(f.ManagedRuleIDs).To(Equal([]string{"fr_removed"}))
+}
+
 func TestFeatureDelete(t *testing.T) {
 	g := NewWithT(t)
 
@@ -78,23 +217,55 @@ func TestFeatureDelete(t *testing.T) {
 	}
 
 	feature := Feature{
-		ID: "feature",
+		ID:           "feature",
+		Organization: "org",
 	}
 
-	err := DeleteFeature(context.TODO(), feature, db)
+	err := DeleteFeature(context.TODO(), feature, db, &ChangeSet{})
 	g.Expect(err).To(BeNil())
 	g.Expect(deleteFilter).To(Equal(bson.M{
-		"id": "feature",
+		"id":           "feature",
+		"organization": "org",
 	}))
 }
 
+func TestFeatureArchive(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter bson.M
+	var updateDoc bson.D
+	db := &MockDatabase{
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter.(bson.M)
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	feature := Feature{
+		ID:           "feature",
+		Organization: "org",
+	}
+
+	err := ArchiveFeature(context.TODO(), feature, db, &ChangeSet{})
+	g.Expect(err).To(BeNil())
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "feature",
+		"organization": "org",
+	}))
+
+	set := updateDoc[0].Value.(bson.M)
+	g.Expect(set["archived"]).To(BeTrue())
+	g.Expect(set["dateUpdated"]).To(BeAssignableToTypeOf(time.Time{}))
+}
+
 func TestFeatureCreateIfNotExists(t *testing.T) {
 	g := NewWithT(t)
 
 	var insertedDoc Feature
 	db := &MockDatabase{
 		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
-			return nil, errors.New("does not exists")
+			return nil, mongo.ErrNoDocuments
 		},
 		InsertOne: func(ctx context.Context, doc interface{}) error {
 			insertedDoc = doc.(Feature)
@@ -106,7 +277,7 @@ func TestFeatureCreateIfNotExists(t *testing.T) {
 		ID: "feature",
 	}
 
-	err := UpdateFeature(context.TODO(), feature, db)
+	err := UpdateFeature(context.TODO(), feature, db, &ChangeSet{})
 	g.Expect(err).To(BeNil())
 	g.Expect(insertedDoc.ID).To(Equal(feature.ID))
 }
@@ -143,7 +314,7 @@ func TestFeatureNoUpdate(t *testing.T) {
 		},
 	}
 
-	err := UpdateFeature(context.TODO(), feature, db)
+	err := UpdateFeature(context.TODO(), feature, db, &ChangeSet{})
 	g.Expect(err).To(BeNil())
 }
 
@@ -159,6 +330,7 @@ func TestFeatureUpdate(t *testing.T) {
 				decode: func(dst interface{}) error {
 					dst.(*Feature).ID = "id"
 					dst.(*Feature).DefaultValue = "current-value"
+					dst.(*Feature).Revision = 2
 					dst.(*Feature).EnvironmentSettings = map[string]EnvironmentSetting{
 						"dev": {
 							Rules: []FeatureRule{
@@ -188,6 +360,7 @@ func TestFeatureUpdate(t *testing.T) {
 
 	feature := Feature{
 		ID:           "id",
+		Organization: "org",
 		DefaultValue: "new-value",
 		EnvironmentSettings: map[string]EnvironmentSetting{
 			"dev": {
@@ -203,11 +376,13 @@ func TestFeatureUpdate(t *testing.T) {
 
 	expectedDoc, _ := bson.Marshal(feature)
 	expectedFilter := bson.M{
-		"id": feature.ID,
+		"id":           feature.ID,
+		"organization": feature.Organization,
+		"__v":          2,
 	}
 
 	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
-	err := UpdateFeature(context.TODO(), feature, db)
+	err := UpdateFeature(context.TODO(), feature, db, &ChangeSet{})
 	g.Expect(err).To(BeNil())
 
 	updateDocSet := updateDoc.(primitive.D)
@@ -239,4 +414,360 @@ func TestFeatureUpdate(t *testing.T) {
 
 	g.Expect(dateUpdated.After(beforeUpdate)).To(BeTrue())
 	g.Expect(updateFilter).To(Equal(expectedFilter))
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+}
+
+func TestFeatureNoUpdateMultipleEnvironments(t *testing.T) {
+	g := NewWithT(t)
+
+	environments := func() map[string]EnvironmentSetting {
+		return map[string]EnvironmentSetting{
+			"dev":        {Enabled: true, Rules: []FeatureRule{{ID: "fr_dev", Type: FeatureRuleTypeForce, Value: "true"}}},
+			"staging":    {Enabled: true},
+			"production": {Enabled: false},
+			"test":       {Enabled: true},
+		}
+	}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Feature).ID = "id"
+					dst.(*Feature).Organization = "org"
+					dst.(*Feature).Tags = []string{}
+					dst.(*Feature).Environments = []string{}
+					dst.(*Feature).EnvironmentSettings = environments()
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			return errors.New("unchanged feature must not be written")
+		},
+	}
+
+	for i := 0; i < 50; i++ {
+		changes := &ChangeSet{}
+		err := UpdateFeature(context.TODO(), Feature{
+			ID:                  "id",
+			Organization:        "org",
+			Tags:                []string{},
+			Environments:        []string{},
+			EnvironmentSettings: environments(),
+		}, db, changes)
+		g.Expect(err).To(BeNil())
+		g.Expect(changes.FeatureChanges()).To(BeEmpty())
+	}
+}
+
+func TestFeatureUpdateConflict(t *testing.T) {
+	g := NewWithT(t)
+
+	var reads int
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			reads++
+			revision := reads
+
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Feature).ID = "id"
+					dst.(*Feature).Description = fmt.Sprintf("changed by ui %d", revision)
+					dst.(*Feature).Revision = revision
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			// The document has been modified between the first read and the update
+			if reads == 1 {
+				return storage.ErrNoMatch
+			}
+
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	feature := Feature{
+		ID:           "id",
+		Organization: "org",
+		DefaultValue: "value",
+	}
+
+	err := UpdateFeature(context.TODO(), feature, db, &ChangeSet{})
+	g.Expect(err).To(BeNil())
+	g.Expect(reads).To(Equal(2))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           feature.ID,
+		"organization": feature.Organization,
+		"__v":          2,
+	}))
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateBSON.Lookup("defaultValue").StringValue()).To(Equal("value"))
+}
+
+func TestFeatureUpdateConflictExceeded(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Feature).ID = "id"
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			return storage.ErrNoMatch
+		},
+	}
+
+	feature := Feature{
+		ID:           "id",
+		DefaultValue: "value",
+	}
+
+	err := UpdateFeature(context.TODO(), feature, db, &ChangeSet{})
+	g.Expect(errors.Is(err, ErrConflict)).To(BeTrue())
+}
+
+func TestFeatureDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Feature).ID = "id"
+					dst.(*Feature).DefaultValue = "changed-value"
+					dst.(*Feature).EnvironmentSettings = map[string]EnvironmentSetting{
+						"dev": {
+							Enabled: true,
+						},
+					}
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	feature := Feature{
+		ID:           "id",
+		DefaultValue: "value",
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"dev": {},
+		},
+	}
+
+	fields, err := DiffFeature(context.TODO(), feature, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"defaultValue", "environmentSettings.dev.enabled"}))
+}
+
+func TestFeatureDiffNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+	}
+
+	fields, err := DiffFeature(context.TODO(), Feature{ID: "id"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(BeEmpty())
+}
+
+func TestFeatureDiffLookupError(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, errors.New("connection refused")
+		},
+	}
+
+	fields, err := DiffFeature(context.TODO(), Feature{ID: "id"}, db)
+	g.Expect(err).To(MatchError("connection refused"))
+	g.Expect(fields).To(BeEmpty())
+}
+
+func TestFeatureUpdateLookupError(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, errors.New("connection refused")
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			t.Fatal("document must not be inserted if the lookup failed")
+			return nil
+		},
+	}
+
+	err := UpdateFeature(context.TODO(), Feature{ID: "id"}, db, &ChangeSet{})
+	g.Expect(err).To(MatchError("connection refused"))
+}
+
+func TestFeatureUpdateInvalidatesPayloads(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Feature).ID = "id"
+					dst.(*Feature).Organization = "org"
+					dst.(*Feature).EnvironmentSettings = map[string]EnvironmentSetting{
+						"dev":        {Enabled: false},
+						"production": {Enabled: true},
+					}
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			return nil
+		},
+	}
+
+	feature := Feature{
+		ID:           "id",
+		Organization: "org",
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"dev":        {Enabled: true},
+			"production": {Enabled: true},
+		},
+	}
+
+	changes := &ChangeSet{}
+	err := UpdateFeature(context.TODO(), feature, db, changes)
+	g.Expect(err).To(BeNil())
+	g.Expect(changes.environments).To(Equal(map[string]map[string]struct{}{
+		"org": {"dev": {}},
+	}))
+
+	g.Expect(changes.FeatureChanges()).To(HaveLen(1))
+	change := changes.FeatureChanges()[0]
+	g.Expect(change.Event).To(Equal(FeatureEventUpdated))
+	g.Expect(change.Environments).To(Equal([]string{"dev"}))
+	g.Expect(change.Feature.EnvironmentSettings["dev"].Enabled).To(BeTrue())
+	g.Expect(change.Previous).NotTo(BeNil())
+	g.Expect(change.Previous.EnvironmentSettings["dev"].Enabled).To(BeFalse())
+}
+
+func TestFeatureDeleteInvalidatesPayloads(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Feature).ID = "id"
+					dst.(*Feature).Organization = "org"
+					dst.(*Feature).EnvironmentSettings = map[string]EnvironmentSetting{
+						"dev":        {},
+						"production": {},
+					}
+					return nil
+				},
+			}, nil
+		},
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			return nil
+		},
+	}
+
+	changes := &ChangeSet{}
+	err := DeleteFeature(context.TODO(), Feature{ID: "id", Organization: "org"}, db, changes)
+	g.Expect(err).To(BeNil())
+	g.Expect(changes.environments).To(Equal(map[string]map[string]struct{}{
+		"org": {"dev": {}, "production": {}},
+	}))
+
+	g.Expect(changes.FeatureChanges()).To(HaveLen(1))
+	g.Expect(changes.FeatureChanges()[0].Event).To(Equal(FeatureEventDeleted))
+	g.Expect(changes.FeatureChanges()[0].Feature.ID).To(Equal("id"))
+	g.Expect(changes.FeatureChanges()[0].Previous).To(BeNil())
+}
+
+func TestTouchedEnvironments(t *testing.T) {
+	g := NewWithT(t)
+
+	from := Feature{
+		ID: "id",
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"dev":        {},
+			"production": {Enabled: true},
+			"staging":    {},
+		},
+	}
+
+	to := Feature{
+		ID: "id",
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"dev":        {},
+			"production": {Enabled: false},
+			"test":       {},
+		},
+	}
+
+	g.Expect(touchedEnvironments(from, to)).To(Equal([]string{"production", "staging", "test"}))
+
+	to.DefaultValue = "changed"
+	g.Expect(touchedEnvironments(from, to)).To(Equal([]string{"dev", "production", "staging", "test"}))
+	g.Expect(touchedEnvironments(from, from)).To(BeEmpty())
+
+	written := from
+	written.DateUpdated = time.Now()
+	written.Revision = 2
+	g.Expect(featureFieldsChanged(from, written)).To(BeFalse())
+	g.Expect(touchedEnvironments(from, written)).To(BeEmpty())
+
+	written.Description = "changed"
+	g.Expect(featureFieldsChanged(from, written)).To(BeTrue())
+}
+
+func TestFeatureFromV1beta1ExperimentRef(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookFeature{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+		Spec: v1beta1.GrowthbookFeatureSpec{
+			Environments: []v1beta1.Environment{
+				{
+					Name: "dev",
+					Rules: []v1beta1.FeatureRule{
+						{
+							Type:         v1beta1.FeatureRuleTypeExperimentRef,
+							ExperimentID: "exp",
+							Variations: []v1beta1.ExperimentRefVariation{
+								{
+									VariationId: "var_a",
+									Value:       "a",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	f := &Feature{}
+	f.FromV1beta1(apiSpec)
+	rule := f.EnvironmentSettings["dev"].Rules[0]
+	g.Expect(rule.ExperimentID).To(Equal("exp"))
+	g.Expect(rule.Variations).To(Equal([]ExperimentRefVariation{{VariationId: "var_a", Value: "a"}}))
 }
@@ -0,0 +1,199 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Metric is a metric experiments are analyzed with
+type Metric struct {
+	ID              string                `bson:"id"`
+	Organization    string                `bson:"organization"`
+	Owner           string                `bson:"owner"`
+	DataSource      string                `bson:"datasource"`
+	Name            string                `bson:"name"`
+	Description     string                `bson:"description"`
+	Type            string                `bson:"type"`
+	Inverse         bool                  `bson:"inverse"`
+	IgnoreNulls     bool                  `bson:"ignoreNulls"`
+	QueryFormat     string                `bson:"queryFormat"`
+	SQL             string                `bson:"sql"`
+	UserIDTypes     []string              `bson:"userIdTypes"`
+	Table           string                `bson:"table"`
+	Column          string                `bson:"column"`
+	Conditions      []MetricCondition     `bson:"conditions"`
+	CappingSettings MetricCappingSettings `bson:"cappingSettings"`
+	WindowSettings  MetricWindowSettings  `bson:"windowSettings"`
+	Tags            []string              `bson:"tags"`
+	Projects        []string              `bson:"projects"`
+	DateCreated     time.Time             `bson:"dateCreated"`
+	DateUpdated     time.Time             `bson:"dateUpdated"`
+	Revision        int                   `bson:"__v"`
+}
+
+type MetricCondition struct {
+	Column   string `bson:"column"`
+	Operator string `bson:"operator"`
+	Value    string `bson:"value"`
+}
+
+type MetricCappingSettings struct {
+	Type  string  `bson:"type"`
+	Value float64 `bson:"value"`
+}
+
+type MetricWindowSettings struct {
+	Type        string `bson:"type"`
+	DelayHours  int64  `bson:"delayHours"`
+	WindowValue int64  `bson:"windowValue"`
+	WindowUnit  string `bson:"windowUnit"`
+}
+
+func (m *Metric) FromV1beta1(metric v1beta1.GrowthbookMetric) *Metric {
+	m.ID = metric.GetID()
+	m.Name = metric.GetName()
+	m.Description = metric.Spec.Description
+	m.Owner = metric.Spec.Owner
+	m.Type = string(metric.Spec.Type)
+	m.Inverse = metric.Spec.Inverse
+	m.IgnoreNulls = metric.Spec.IgnoreNulls
+	m.QueryFormat = string(metric.Spec.QueryFormat)
+	m.SQL = metric.Spec.SQL
+	m.UserIDTypes = append([]string{}, metric.Spec.UserIDTypes...)
+	m.Table = metric.Spec.Table
+	m.Column = metric.Spec.Column
+	m.Tags = append([]string{}, metric.Spec.Tags...)
+
+	// The data source and the projects are referenced by resource name and need to be resolved by the caller
+	m.DataSource = metric.Spec.DataSource
+	m.Projects = append([]string{}, metric.Spec.Projects...)
+
+	if m.QueryFormat == "" {
+		m.QueryFormat = string(v1beta1.MetricQueryFormatSQL)
+	}
+
+	m.Conditions = []MetricCondition{}
+	for _, condition := range metric.Spec.Conditions {
+		operator := condition.Operator
+		if operator == "" {
+			operator = "="
+		}
+
+		m.Conditions = append(m.Conditions, MetricCondition{
+			Column:   condition.Column,
+			Operator: operator,
+			Value:    condition.Value,
+		})
+	}
+
+	// Metrics without a cap or a window use the growthbook defaults
+	m.CappingSettings = MetricCappingSettings{}
+	if metric.Spec.Cap != nil {
+		value, _ := strconv.ParseFloat(metric.Spec.Cap.Value, 64)
+		m.CappingSettings = MetricCappingSettings{
+			Type:  metric.Spec.Cap.Type,
+			Value: value,
+		}
+	}
+
+	m.WindowSettings = MetricWindowSettings{
+		WindowValue: 72,
+		WindowUnit:  "hours",
+	}
+
+	if metric.Spec.Window != nil {
+		m.WindowSettings.Type = metric.Spec.Window.Type
+		m.WindowSettings.DelayHours = metric.Spec.Window.DelayHours
+
+		if m.WindowSettings.Type == "" {
+			m.WindowSettings.Type = "conversion"
+		}
+
+		if metric.Spec.Window.Value != 0 {
+			m.WindowSettings.WindowValue = metric.Spec.Window.Value
+		}
+
+		if metric.Spec.Window.Unit != "" {
+			m.WindowSettings.WindowUnit = metric.Spec.Window.Unit
+		}
+	}
+
+	return m
+}
+
+func DeleteMetric(ctx context.Context, metric Metric, db storage.Database) error {
+	col := db.Collection("metrics")
+	filter := bson.M{
+		"id":           metric.ID,
+		"organization": metric.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateMetric(ctx context.Context, metric Metric, db storage.Database) error {
+	col := db.Collection("metrics")
+	filter := bson.M{
+		"id":           metric.ID,
+		"organization": metric.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, metric, documentUpdate[Metric]{
+		name:  fmt.Sprintf("metric %s in organization %s", metric.ID, metric.Organization),
+		merge: mergeFunc(mergeMetric),
+		create: func(metric Metric) (Metric, error) {
+			metric.DateCreated = time.Now()
+			metric.DateUpdated = metric.DateCreated
+			return metric, nil
+		},
+	})
+
+	return err
+}
+
+func GetMetricMeta(ctx context.Context, metric Metric, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("metrics"), bson.M{
+		"id":           metric.ID,
+		"organization": metric.Organization,
+	})
+}
+
+func DiffMetric(ctx context.Context, metric Metric, db storage.Database) ([]string, error) {
+	col := db.Collection("metrics")
+	filter := bson.M{
+		"id":           metric.ID,
+		"organization": metric.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, metric, mergeFunc(mergeMetric))
+}
+
+func mergeMetric(existing, metric Metric) Metric {
+	existing.ID = metric.ID
+	existing.Organization = metric.Organization
+	existing.Owner = metric.Owner
+	existing.DataSource = metric.DataSource
+	existing.Name = metric.Name
+	existing.Description = metric.Description
+	existing.Type = metric.Type
+	existing.Inverse = metric.Inverse
+	existing.IgnoreNulls = metric.IgnoreNulls
+	existing.QueryFormat = metric.QueryFormat
+	existing.SQL = metric.SQL
+	existing.UserIDTypes = metric.UserIDTypes
+	existing.Table = metric.Table
+	existing.Column = metric.Column
+	existing.Conditions = metric.Conditions
+	existing.CappingSettings = metric.CappingSettings
+	existing.WindowSettings = metric.WindowSettings
+	existing.Tags = metric.Tags
+	existing.Projects = metric.Projects
+
+	return existing
+}
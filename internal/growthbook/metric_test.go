@@ -0,0 +1,194 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestMetricFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookMetric{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookMetricSpec{
+			Description: "foo",
+			DataSource:  "warehouse",
+			Type:        v1beta1.MetricTypeRevenue,
+			Inverse:     true,
+			SQL:         "SELECT user_id, timestamp, amount AS value FROM orders",
+			UserIDTypes: []string{"user_id"},
+			Cap: &v1beta1.MetricCap{
+				Type:  "percentile",
+				Value: "0.99",
+			},
+			Window: &v1beta1.MetricWindow{
+				DelayHours: 1,
+				Value:      7,
+				Unit:       "days",
+			},
+		},
+	}
+
+	m := &Metric{}
+	m.FromV1beta1(apiSpec)
+	g.Expect(m.ID).To(Equal(apiSpec.Name))
+	g.Expect(m.Name).To(Equal(apiSpec.Name))
+	g.Expect(m.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(m.DataSource).To(Equal("warehouse"))
+	g.Expect(m.Type).To(Equal("revenue"))
+	g.Expect(m.Inverse).To(BeTrue())
+	g.Expect(m.QueryFormat).To(Equal("sql"))
+	g.Expect(m.SQL).To(Equal(apiSpec.Spec.SQL))
+	g.Expect(m.UserIDTypes).To(Equal([]string{"user_id"}))
+	g.Expect(m.CappingSettings).To(Equal(MetricCappingSettings{Type: "percentile", Value: 0.99}))
+	g.Expect(m.WindowSettings).To(Equal(MetricWindowSettings{
+		Type:        "conversion",
+		DelayHours:  1,
+		WindowValue: 7,
+		WindowUnit:  "days",
+	}))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Cap = nil
+	apiSpec.Spec.Window = nil
+	apiSpec.Spec.Conditions = []v1beta1.MetricCondition{{Column: "event", Value: "purchase"}}
+	m.FromV1beta1(apiSpec)
+	g.Expect(m.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(m.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(m.CappingSettings).To(Equal(MetricCappingSettings{}))
+	g.Expect(m.WindowSettings).To(Equal(MetricWindowSettings{WindowValue: 72, WindowUnit: "hours"}))
+	g.Expect(m.Conditions).To(Equal([]MetricCondition{{Column: "event", Operator: "=", Value: "purchase"}}))
+}
+
+func TestMetricDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteMetric(context.TODO(), Metric{ID: "metric", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "metric",
+		"organization": "org",
+	}))
+}
+
+func TestMetricCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Metric
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Metric)
+			return nil
+		},
+	}
+
+	err := UpdateMetric(context.TODO(), Metric{ID: "metric", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("metric"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestMetricNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Metric).ID = "metric"
+					dst.(*Metric).Organization = "org"
+					dst.(*Metric).Type = "binomial"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateMetric(context.TODO(), Metric{ID: "metric", Organization: "org", Type: "binomial"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestMetricUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Metric).ID = "metric"
+					dst.(*Metric).Organization = "org"
+					dst.(*Metric).Type = "count"
+					dst.(*Metric).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateMetric(context.TODO(), Metric{ID: "metric", Organization: "org", Type: "binomial"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("type").StringValue()).To(Equal("binomial"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "metric",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestMetricDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Metric).ID = "metric"
+					dst.(*Metric).Organization = "org"
+					dst.(*Metric).Type = "count"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffMetric(context.TODO(), Metric{ID: "metric", Organization: "org", Type: "binomial"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"type"}))
+}
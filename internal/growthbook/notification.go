@@ -0,0 +1,376 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Notification is a signed http request which informs a third party about changes in growthbook
+type Notification struct {
+	URL     string
+	Method  string
+	Headers map[string]string
+	Body    []byte
+}
+
+func (n Notification) String() string {
+	return fmt.Sprintf("%s %s", n.Method, n.URL)
+}
+
+// Send sends the notification and fails if the response status is not successful
+func (n Notification) Send(ctx context.Context, client *http.Client) error {
+	var body io.Reader
+	if n.Method != http.MethodGet {
+		body = bytes.NewReader(n.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, n.Method, n.URL, body)
+	if err != nil {
+		return err
+	}
+
+	for k, v := range n.Headers {
+		req.Header.Set(k, v)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return fmt.Errorf("%s failed with status %d", n, res.StatusCode)
+	}
+
+	return nil
+}
+
+// NewSDKWebhookNotification returns a notification for the given sdk webhook signed the same way as growthbook does.
+// It contains a legacy signature header as well as the headers defined by the standard webhooks specification.
+func NewSDKWebhookNotification(webhook SDKWebhook, sdkconnection SDKConnection, payload []byte, now time.Time) Notification {
+	body := []byte("{}")
+	if webhook.SendPayload && payload != nil {
+		body = payload
+	}
+
+	method := webhook.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	id := md5.Sum([]byte(sdkconnection.Key + webhook.Endpoint + string(body)))
+	webhookID := "msg_" + hex.EncodeToString(id[:])
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+
+	headers := parseHeaders(webhook.Headers)
+	headers["Content-Type"] = "application/json"
+	headers["X-GrowthBook-Signature"] = hex.EncodeToString(sign(webhook.SigningKey, body))
+	headers["webhook-id"] = webhookID
+	headers["webhook-timestamp"] = timestamp
+	headers["webhook-signature"] = "v1," + base64.StdEncoding.EncodeToString(sign(webhook.SigningKey, []byte(webhookID+"."+timestamp+"."+string(body))))
+
+	return Notification{
+		URL:     webhook.Endpoint,
+		Method:  method,
+		Headers: headers,
+		Body:    body,
+	}
+}
+
+// NewProxyNotification returns a notification which tells the growthbook proxy to refresh the payload of the sdk connection
+func NewProxyNotification(sdkconnection SDKConnection, payload []byte) Notification {
+	return Notification{
+		URL:    strings.TrimRight(sdkconnection.Proxy.Host, "/") + "/proxy/features",
+		Method: http.MethodPost,
+		Headers: map[string]string{
+			"Content-Type":           "application/json",
+			"X-GrowthBook-Api-Key":   sdkconnection.Key,
+			"X-GrowthBook-Signature": hex.EncodeToString(sign(sdkconnection.Proxy.SigningKey, payload)),
+		},
+		Body: payload,
+	}
+}
+
+// eventAPIVersion is the version of the event payload schema sent by growthbook
+const eventAPIVersion = "2024-07-31"
+
+// featureEvent is the payload growthbook sends to event webhooks for feature events
+type featureEvent struct {
+	Event      FeatureEvent     `json:"event"`
+	Object     string           `json:"object"`
+	APIVersion string           `json:"api_version"`
+	Created    int64            `json:"created"`
+	Data       featureEventData `json:"data"`
+	// User is the growthbook user who made the change, changes made by the controller are not attributed to a user
+	User            *struct{} `json:"user"`
+	Tags            []string  `json:"tags"`
+	Environments    []string  `json:"environments"`
+	ContainsSecrets bool      `json:"containsSecrets"`
+}
+
+type featureEventData struct {
+	Object apiFeature `json:"object"`
+	// PreviousAttributes holds the previous values of all attributes changed by an update
+	PreviousAttributes map[string]interface{} `json:"previous_attributes,omitempty"`
+}
+
+// apiFeature is the representation of a feature in the growthbook REST API
+type apiFeature struct {
+	ID           string                           `json:"id"`
+	DateCreated  string                           `json:"dateCreated"`
+	DateUpdated  string                           `json:"dateUpdated"`
+	Archived     bool                             `json:"archived"`
+	Description  string                           `json:"description"`
+	Owner        string                           `json:"owner"`
+	Project      string                           `json:"project"`
+	ValueType    FeatureValueType                 `json:"valueType"`
+	DefaultValue string                           `json:"defaultValue"`
+	Tags         []string                         `json:"tags"`
+	Environments map[string]apiFeatureEnvironment `json:"environments"`
+}
+
+type apiFeatureEnvironment struct {
+	Enabled      bool             `json:"enabled"`
+	DefaultValue string           `json:"defaultValue"`
+	Rules        []apiFeatureRule `json:"rules"`
+}
+
+// apiFeatureRule is a feature rule in the growthbook REST API, the fields depend on the rule type
+type apiFeatureRule struct {
+	ID                string                  `json:"id"`
+	Type              FeatureRuleType         `json:"type"`
+	Description       string                  `json:"description"`
+	Condition         string                  `json:"condition"`
+	Enabled           bool                    `json:"enabled"`
+	Value             interface{}             `json:"value,omitempty"`
+	Coverage          *float64                `json:"coverage,omitempty"`
+	HashAttribute     string                  `json:"hashAttribute,omitempty"`
+	FallbackAttribute *string                 `json:"fallbackAttribute,omitempty"`
+	TrackingKey       string                  `json:"trackingKey,omitempty"`
+	ExperimentID      string                  `json:"experimentId,omitempty"`
+	Variations        []apiFeatureRuleVariant `json:"variations,omitempty"`
+}
+
+type apiFeatureRuleValue struct {
+	Value  string  `json:"value"`
+	Weight float64 `json:"weight"`
+	Name   string  `json:"name,omitempty"`
+}
+
+type apiFeatureRuleVariant struct {
+	Value       string `json:"value"`
+	VariationID string `json:"variationId"`
+}
+
+func newAPIFeature(feature Feature) apiFeature {
+	f := apiFeature{
+		ID:           feature.ID,
+		DateCreated:  formatISODate(feature.DateCreated),
+		DateUpdated:  formatISODate(feature.DateUpdated),
+		Archived:     feature.Archived,
+		Description:  feature.Description,
+		Owner:        feature.Owner,
+		Project:      feature.Project,
+		ValueType:    feature.ValueType,
+		DefaultValue: feature.DefaultValue,
+		Tags:         append([]string{}, feature.Tags...),
+		Environments: make(map[string]apiFeatureEnvironment),
+	}
+
+	for env, settings := range feature.EnvironmentSettings {
+		environment := apiFeatureEnvironment{
+			Enabled:      settings.Enabled,
+			DefaultValue: feature.DefaultValue,
+			Rules:        []apiFeatureRule{},
+		}
+
+		for _, rule := range settings.Rules {
+			r := apiFeatureRule{
+				ID:          rule.ID,
+				Type:        rule.Type,
+				Description: rule.Description,
+				Condition:   rule.Condition,
+				Enabled:     rule.Enabled,
+			}
+
+			switch rule.Type {
+			case FeatureRuleTypeForce:
+				r.Value = rule.Value
+			case FeatureRuleTypeRollout:
+				r.Value = rule.Value
+				r.Coverage = &rule.Coverage
+				r.HashAttribute = rule.HashAttribute
+			case FeatureRuleTypeExperiment:
+				values := []apiFeatureRuleValue{}
+				for _, value := range rule.Values {
+					v := apiFeatureRuleValue{Value: value.Value, Weight: value.Weight}
+					if value.Name != nil {
+						v.Name = *value.Name
+					}
+
+					values = append(values, v)
+				}
+
+				r.Value = values
+				r.Coverage = &rule.Coverage
+				r.HashAttribute = rule.HashAttribute
+				r.FallbackAttribute = rule.FallbackAttribute
+				r.TrackingKey = rule.TrackingKey
+			case FeatureRuleTypeExperimentRef:
+				r.ExperimentID = rule.ExperimentID
+				r.Variations = []apiFeatureRuleVariant{}
+				for _, variation := range rule.Variations {
+					r.Variations = append(r.Variations, apiFeatureRuleVariant{Value: variation.Value, VariationID: variation.VariationId})
+				}
+			}
+
+			environment.Rules = append(environment.Rules, r)
+		}
+
+		f.Environments[env] = environment
+	}
+
+	return f
+}
+
+// previousAttributes returns the previous values of all top level attributes which differ between both features
+func previousAttributes(previous, current apiFeature) (map[string]interface{}, error) {
+	toMap := func(f apiFeature) (map[string]interface{}, error) {
+		b, err := json.Marshal(f)
+		if err != nil {
+			return nil, err
+		}
+
+		var m map[string]interface{}
+		return m, json.Unmarshal(b, &m)
+	}
+
+	before, err := toMap(previous)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := toMap(current)
+	if err != nil {
+		return nil, err
+	}
+
+	changed := make(map[string]interface{})
+	for key, value := range before {
+		if !reflect.DeepEqual(value, after[key]) {
+			changed[key] = value
+		}
+	}
+
+	return changed, nil
+}
+
+// formatISODate formats a date the same way as javascript Date.toISOString
+func formatISODate(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+// NewEventNotification returns a notification for the given event webhook about a changed feature.
+// The body follows the event payload schema of growthbook.
+func NewEventNotification(webhook EventWebhook, change FeatureChange, now time.Time) (Notification, error) {
+	event := featureEvent{
+		Event:        change.Event,
+		Object:       "feature",
+		APIVersion:   eventAPIVersion,
+		Created:      now.UnixMilli(),
+		Data:         featureEventData{Object: newAPIFeature(change.Feature)},
+		Tags:         append([]string{}, change.Feature.Tags...),
+		Environments: append([]string{}, change.Environments...),
+	}
+
+	if change.Previous != nil {
+		attributes, err := previousAttributes(newAPIFeature(*change.Previous), event.Data.Object)
+		if err != nil {
+			return Notification{}, err
+		}
+
+		event.Data.PreviousAttributes = attributes
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return Notification{}, err
+	}
+
+	method := webhook.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	timestamp := strconv.FormatInt(now.Unix(), 10)
+	headers := parseHeaders(webhook.Headers)
+	headers["Content-Type"] = "application/json"
+	headers["X-GrowthBook-Signature"] = hex.EncodeToString(sign(webhook.SigningKey, body))
+	headers["webhook-id"] = "msg_" + change.ID + "_" + timestamp
+	headers["webhook-timestamp"] = timestamp
+	headers["webhook-signature"] = "v1," + base64.StdEncoding.EncodeToString(sign(webhook.SigningKey, []byte(headers["webhook-id"]+"."+timestamp+"."+string(body))))
+
+	return Notification{
+		URL:     webhook.URL,
+		Method:  method,
+		Headers: headers,
+		Body:    body,
+	}, nil
+}
+
+// FetchSDKPayload fetches the current payload of an sdk connection from the growthbook api
+func FetchSDKPayload(ctx context.Context, client *http.Client, apiHost string, sdkconnection SDKConnection) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/api/features/%s", strings.TrimRight(apiHost, "/"), sdkconnection.Key), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer res.Body.Close()
+	payload, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed fetching sdk payload for %s with status %d", sdkconnection.Key, res.StatusCode)
+	}
+
+	return payload, nil
+}
+
+func sign(key string, data []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+// parseHeaders parses the custom headers of a webhook which growthbook stores as json object
+func parseHeaders(headers string) map[string]string {
+	result := make(map[string]string)
+	if headers != "" {
+		_ = json.Unmarshal([]byte(headers), &result)
+	}
+
+	return result
+}
@@ -0,0 +1,211 @@
+package growthbook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestSDKWebhookNotification(t *testing.T) {
+	g := NewWithT(t)
+
+	webhook := SDKWebhook{
+		Endpoint:    "http://webhook/sdk",
+		SigningKey:  "wk_secret",
+		SendPayload: true,
+		Headers:     `{"X-Custom":"value"}`,
+	}
+
+	payload := []byte(`{"features":{}}`)
+	now := time.Unix(1700000000, 0)
+	n := NewSDKWebhookNotification(webhook, SDKConnection{Key: "sdk-key"}, payload, now)
+
+	g.Expect(n.URL).To(Equal(webhook.Endpoint))
+	g.Expect(n.Method).To(Equal(http.MethodPost))
+	g.Expect(n.Body).To(Equal(payload))
+	g.Expect(n.Headers["X-Custom"]).To(Equal("value"))
+	g.Expect(n.Headers["webhook-timestamp"]).To(Equal("1700000000"))
+
+	mac := hmac.New(sha256.New, []byte(webhook.SigningKey))
+	mac.Write(payload)
+	g.Expect(n.Headers["X-GrowthBook-Signature"]).To(Equal(hex.EncodeToString(mac.Sum(nil))))
+
+	mac = hmac.New(sha256.New, []byte(webhook.SigningKey))
+	mac.Write([]byte(n.Headers["webhook-id"] + ".1700000000." + string(payload)))
+	g.Expect(n.Headers["webhook-signature"]).To(Equal("v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))))
+
+	webhook.SendPayload = false
+	n = NewSDKWebhookNotification(webhook, SDKConnection{Key: "sdk-key"}, payload, now)
+	g.Expect(n.Body).To(Equal([]byte("{}")))
+}
+
+func TestProxyNotification(t *testing.T) {
+	g := NewWithT(t)
+
+	sdkconnection := SDKConnection{
+		Key: "sdk-key",
+		Proxy: SDKConnectionProxy{
+			Enabled:    true,
+			Host:       "http://proxy:3300/",
+			SigningKey: "secret",
+		},
+	}
+
+	payload := []byte(`{"features":{}}`)
+	n := NewProxyNotification(sdkconnection, payload)
+
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+
+	g.Expect(n.URL).To(Equal("http://proxy:3300/proxy/features"))
+	g.Expect(n.Headers["X-GrowthBook-Api-Key"]).To(Equal("sdk-key"))
+	g.Expect(n.Headers["X-GrowthBook-Signature"]).To(Equal(hex.EncodeToString(mac.Sum(nil))))
+	g.Expect(n.Body).To(Equal(payload))
+}
+
+func TestEventNotification(t *testing.T) {
+	g := NewWithT(t)
+
+	webhook := EventWebhook{
+		URL:        "http://webhook/events",
+		SigningKey: "secret",
+	}
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6000000, time.UTC)
+	feature := Feature{
+		ID:           "feature",
+		Organization: "org",
+		Description:  "new",
+		Owner:        "team",
+		Project:      "frontend",
+		ValueType:    FeatureValueTypeBoolean,
+		DefaultValue: "false",
+		Tags:         []string{"tag"},
+		DateCreated:  created,
+		DateUpdated:  created.Add(time.Hour),
+		EnvironmentSettings: map[string]EnvironmentSetting{
+			"production": {
+				Enabled: true,
+				Rules: []FeatureRule{
+					{
+						ID:          "fr_force",
+						Type:        FeatureRuleTypeForce,
+						Description: "internal users",
+						Condition:   `{"internal":true}`,
+						Enabled:     true,
+						Value:       "true",
+					},
+				},
+			},
+		},
+	}
+
+	previous := feature
+	previous.Description = "old"
+	previous.DateUpdated = created
+
+	n, err := NewEventNotification(webhook, FeatureChange{
+		Organization: "org",
+		ID:           "feature",
+		Event:        FeatureEventUpdated,
+		Feature:      feature,
+		Previous:     &previous,
+		Environments: []string{"production"},
+	}, time.UnixMilli(1700000000123))
+	g.Expect(err).To(BeNil())
+
+	g.Expect(string(n.Body)).To(Equal(`{"event":"feature.updated","object":"feature","api_version":"2024-07-31","created":1700000000123,` +
+		`"data":{"object":{"id":"feature","dateCreated":"2024-01-02T03:04:05.006Z","dateUpdated":"2024-01-02T04:04:05.006Z","archived":false,` +
+		`"description":"new","owner":"team","project":"frontend","valueType":"boolean","defaultValue":"false","tags":["tag"],` +
+		`"environments":{"production":{"enabled":true,"defaultValue":"false","rules":[{"id":"fr_force","type":"force",` +
+		`"description":"internal users","condition":"{\"internal\":true}","enabled":true,"value":"true"}]}}},` +
+		`"previous_attributes":{"dateUpdated":"2024-01-02T03:04:05.006Z","description":"old"}},` +
+		`"user":null,"tags":["tag"],"environments":["production"],"containsSecrets":false}`))
+
+	mac := hmac.New(sha256.New, []byte(webhook.SigningKey))
+	mac.Write(n.Body)
+	g.Expect(n.Headers["X-GrowthBook-Signature"]).To(Equal(hex.EncodeToString(mac.Sum(nil))))
+	g.Expect(n.Headers["webhook-timestamp"]).To(Equal("1700000000"))
+
+	n, err = NewEventNotification(webhook, FeatureChange{
+		Organization: "org",
+		ID:           "feature",
+		Event:        FeatureEventCreated,
+		Feature:      Feature{ID: "feature", DateCreated: created, DateUpdated: created},
+	}, time.UnixMilli(1700000000123))
+	g.Expect(err).To(BeNil())
+	g.Expect(string(n.Body)).To(Equal(`{"event":"feature.created","object":"feature","api_version":"2024-07-31","created":1700000000123,` +
+		`"data":{"object":{"id":"feature","dateCreated":"2024-01-02T03:04:05.006Z","dateUpdated":"2024-01-02T03:04:05.006Z","archived":false,` +
+		`"description":"","owner":"","project":"","valueType":"","defaultValue":"","tags":[],"environments":{}}},` +
+		`"user":null,"tags":[],"environments":[],"containsSecrets":false}`))
+}
+
+func TestNotificationSend(t *testing.T) {
+	g := NewWithT(t)
+
+	var received []byte
+	var signature string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get("X-GrowthBook-Signature")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := Notification{
+		URL:     server.URL,
+		Method:  http.MethodPost,
+		Headers: map[string]string{"X-GrowthBook-Signature": "sig"},
+		Body:    []byte("body"),
+	}
+
+	g.Expect(n.Send(context.TODO(), server.Client())).To(Succeed())
+	g.Expect(received).To(Equal([]byte("body")))
+	g.Expect(signature).To(Equal("sig"))
+}
+
+func TestNotificationSendFailed(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	n := Notification{
+		URL:    server.URL,
+		Method: http.MethodPost,
+	}
+
+	g.Expect(n.Send(context.TODO(), server.Client())).NotTo(Succeed())
+}
+
+func TestFetchSDKPayload(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/features/sdk-key" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"features":{}}`))
+	}))
+	defer server.Close()
+
+	payload, err := FetchSDKPayload(context.TODO(), server.Client(), server.URL+"/", SDKConnection{Key: "sdk-key"})
+	g.Expect(err).To(BeNil())
+	g.Expect(payload).To(Equal([]byte(`{"features":{}}`)))
+
+	_, err = FetchSDKPayload(context.TODO(), server.Client(), server.URL, SDKConnection{Key: "unknown"})
+	g.Expect(err).NotTo(BeNil())
+}
@@ -0,0 +1,88 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Notifier sends notifications asynchronously and retries failed ones with an exponential backoff
+type Notifier struct {
+	client     *http.Client
+	log        logr.Logger
+	maxRetries int
+	queue      workqueue.TypedRateLimitingInterface[*Notification]
+}
+
+type NotifierOptions struct {
+	// Client is used to send the notifications
+	Client *http.Client
+	// MaxRetries is the number of retries before a notification is dropped
+	MaxRetries int
+	// BaseDelay is the delay before the first retry which doubles with each further retry
+	BaseDelay time.Duration
+	// MaxDelay is the maximum delay between retries
+	MaxDelay time.Duration
+}
+
+func NewNotifier(log logr.Logger, opts NotifierOptions) *Notifier {
+	if opts.Client == nil {
+		opts.Client = http.DefaultClient
+	}
+
+	return &Notifier{
+		client:     opts.Client,
+		log:        log,
+		maxRetries: opts.MaxRetries,
+		queue: workqueue.NewTypedRateLimitingQueueWithConfig(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[*Notification](opts.BaseDelay, opts.MaxDelay),
+			workqueue.TypedRateLimitingQueueConfig[*Notification]{Name: "notifications"},
+		),
+	}
+}
+
+// Enqueue schedules a notification to be sent
+func (n *Notifier) Enqueue(notification Notification) {
+	n.queue.Add(&notification)
+}
+
+// Start sends queued notifications until the context is done
+func (n *Notifier) Start(ctx context.Context) error {
+	go func() {
+		<-ctx.Done()
+		n.queue.ShutDown()
+	}()
+
+	for n.processNext(ctx) {
+	}
+
+	return nil
+}
+
+func (n *Notifier) processNext(ctx context.Context) bool {
+	notification, shutdown := n.queue.Get()
+	if shutdown {
+		return false
+	}
+
+	defer n.queue.Done(notification)
+
+	err := notification.Send(ctx, n.client)
+	if err == nil {
+		n.queue.Forget(notification)
+		return true
+	}
+
+	if n.queue.NumRequeues(notification) < n.maxRetries {
+		n.log.V(1).Info("notification failed, retrying", "notification", notification.String(), "error", err.Error())
+		n.queue.AddRateLimited(notification)
+		return true
+	}
+
+	n.log.Error(err, "notification failed, giving up", "notification", notification.String())
+	n.queue.Forget(notification)
+	return true
+}
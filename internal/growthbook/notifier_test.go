@@ -0,0 +1,75 @@
+package growthbook
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+)
+
+func TestNotifierRetry(t *testing.T) {
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(logr.Discard(), NotifierOptions{
+		Client:     server.Client(),
+		MaxRetries: 5,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	go func() {
+		_ = notifier.Start(ctx)
+	}()
+
+	notifier.Enqueue(Notification{URL: server.URL, Method: http.MethodPost})
+	g.Eventually(calls.Load).Should(Equal(int32(3)))
+	g.Consistently(calls.Load, 50*time.Millisecond).Should(Equal(int32(3)))
+}
+
+func TestNotifierGivesUp(t *testing.T) {
+	g := NewWithT(t)
+
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier(logr.Discard(), NotifierOptions{
+		Client:     server.Client(),
+		MaxRetries: 2,
+		BaseDelay:  time.Millisecond,
+		MaxDelay:   10 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	go func() {
+		_ = notifier.Start(ctx)
+	}()
+
+	notifier.Enqueue(Notification{URL: server.URL, Method: http.MethodPost})
+	g.Eventually(calls.Load).Should(Equal(int32(3)))
+	g.Consistently(calls.Load, 50*time.Millisecond).Should(Equal(int32(3)))
+}
@@ -1,33 +1,113 @@
 package growthbook
 
 import (
-	"bytes"
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
 	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
 	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
 )
 
+// BuiltinRoles are the roles provided by every growthbook organization
+var BuiltinRoles = []string{"noaccess", "readonly", "collaborator", "visualEditor", "engineer", "analyst", "experimenter", "admin"}
+
 type Organization struct {
 	ID          string               `bson:"id"`
 	OwnerEmail  string               `bson:"ownerEmail"`
 	Name        string               `bson:"name"`
 	DateCreated time.Time            `bson:"dateCreated"`
 	Members     []OrganizationMember `bson:"members"`
+	Settings    bson.D               `bson:"settings,omitempty"`
+	CustomRoles []OrganizationRole   `bson:"customRoles,omitempty"`
 	Revision    int                  `bson:"__v"`
+
+	// Environments are written to the settings if not nil, other settings are retained
+	Environments []OrganizationEnvironment `bson:"-"`
+
+	// Namespaces are written to the settings if not nil, other settings are retained
+	Namespaces []OrganizationNamespace `bson:"-"`
 }
 
 type OrganizationMember struct {
-	ID   string `bson:"id"`
-	Role string `bson:"role"`
+	ID    string   `bson:"id"`
+	Role  string   `bson:"role"`
+	Teams []string `bson:"teams,omitempty"`
+}
+
+type OrganizationNamespace struct {
+	Name        string `bson:"name"`
+	Label       string `bson:"label"`
+	Description string `bson:"description"`
+	Status      string `bson:"status"`
+}
+
+type OrganizationRole struct {
+	ID          string   `bson:"id"`
+	Description string   `bson:"description"`
+	Policies    []string `bson:"policies"`
+}
+
+type OrganizationEnvironment struct {
+	ID           string   `bson:"id"`
+	Description  string   `bson:"description"`
+	ToggleOnList bool     `bson:"toggleOnList"`
+	DefaultState bool     `bson:"defaultState"`
+	Projects     []string `bson:"projects"`
 }
 
 func (o *Organization) FromV1beta1(org v1beta1.GrowthbookOrganization) *Organization {
 	o.Name = org.GetName()
 	o.ID = org.GetID()
 	o.OwnerEmail = org.Spec.OwnerEmail
+
+	if org.Spec.Environments != nil {
+		o.Environments = []OrganizationEnvironment{}
+	}
+
+	if org.Spec.Namespaces != nil {
+		o.Namespaces = []OrganizationNamespace{}
+	}
+
+	for _, namespace := range org.Spec.Namespaces {
+		status := string(namespace.Status)
+		if status == "" {
+			status = string(v1beta1.NamespaceStatusActive)
+		}
+
+		o.Namespaces = append(o.Namespaces, OrganizationNamespace{
+			Name:        namespace.Name,
+			Label:       namespace.Name,
+			Description: namespace.Description,
+			Status:      status,
+		})
+	}
+
+	if org.Spec.CustomRoles != nil {
+		o.CustomRoles = []OrganizationRole{}
+	}
+
+	for _, role := range org.Spec.CustomRoles {
+		o.CustomRoles = append(o.CustomRoles, OrganizationRole{
+			ID:          role.ID,
+			Description: role.Description,
+			Policies:    append([]string{}, role.Permissions...),
+		})
+	}
+
+	// Projects are referenced by resource name and need to be resolved by the caller
+	for _, env := range org.Spec.Environments {
+		o.Environments = append(o.Environments, OrganizationEnvironment{
+			ID:           env.Name,
+			Description:  env.Description,
+			ToggleOnList: env.ToggleOnList,
+			DefaultState: env.DefaultState,
+			Projects:     []string{},
+		})
+	}
+
 	return o
 }
 
@@ -46,27 +126,46 @@ func UpdateOrganization(ctx context.Context, org Organization, db storage.Databa
 		"id": org.ID,
 	}
 
-	var existing Organization
-	result, err := col.FindOne(ctx, filter)
+	_, err := updateDocument(ctx, col, filter, org, documentUpdate[Organization]{
+		name:  fmt.Sprintf("organization %s", org.ID),
+		merge: mergeFunc(mergeOrganization),
+		create: func(org Organization) (Organization, error) {
+			if org.Members == nil {
+				org.Members = []OrganizationMember{}
+			}
 
-	if err != nil {
-		if org.Members == nil {
-			org.Members = []OrganizationMember{}
-		}
+			if org.Environments != nil {
+				org.Settings = setSetting(org.Settings, "environments", org.Environments)
+			}
 
-		org.DateCreated = time.Now()
-		return col.InsertOne(ctx, org)
-	}
+			if org.Namespaces != nil {
+				org.Settings = setSetting(org.Settings, "namespaces", org.Namespaces)
+			}
 
-	if err := result.Decode(&existing); err != nil {
-		return err
-	}
+			org.DateCreated = time.Now()
+			return org, nil
+		},
+	})
+
+	return err
+}
+
+func GetOrganizationMeta(ctx context.Context, org Organization, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("organizations"), bson.M{
+		"id": org.ID,
+	})
+}
 
-	existingBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
+func DiffOrganization(ctx context.Context, org Organization, db storage.Database) ([]string, error) {
+	col := db.Collection("organizations")
+	filter := bson.M{
+		"id": org.ID,
 	}
 
+	return diffDocument(ctx, col, filter, org, mergeFunc(mergeOrganization))
+}
+
+func mergeOrganization(existing, org Organization) Organization {
 	existing.ID = org.ID
 	existing.OwnerEmail = org.OwnerEmail
 	existing.Name = org.Name
@@ -77,18 +176,31 @@ func UpdateOrganization(ctx context.Context, org Organization, db storage.Databa
 		existing.Members = org.Members
 	}
 
-	updateBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
+	// Custom roles are only managed if declared, roles created in growthbook are retained otherwise
+	if org.CustomRoles != nil {
+		existing.CustomRoles = org.CustomRoles
 	}
 
-	if bytes.Equal(existingBson, updateBson) {
-		return nil
+	if org.Environments != nil {
+		existing.Settings = setSetting(existing.Settings, "environments", org.Environments)
 	}
 
-	update := bson.D{
-		{Key: "$set", Value: bson.Raw(updateBson)},
+	if org.Namespaces != nil {
+		existing.Settings = setSetting(existing.Settings, "namespaces", org.Namespaces)
+	}
+
+	return existing
+}
+
+// setSetting replaces or adds a single organization setting while all other settings are retained
+func setSetting(settings bson.D, key string, value interface{}) bson.D {
+	settings = slices.Clone(settings)
+	for i, setting := range settings {
+		if setting.Key == key {
+			settings[i].Value = value
+			return settings
+		}
 	}
 
-	return col.UpdateOne(ctx, filter, update)
+	return append(settings, bson.E{Key: key, Value: value})
 }
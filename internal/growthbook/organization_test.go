@@ -2,7 +2,6 @@ package growthbook
 
 import (
 	"context"
-	"errors"
 	"testing"
 
 	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
@@ -10,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -67,7 +67,7 @@ func TestOrganizationCreateIfNotExists(t *testing.T) {
 	var insertedDoc Organization
 	db := &MockDatabase{
 		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
-			return nil, errors.New("does not exists")
+			return nil, mongo.ErrNoDocuments
 		},
 		InsertOne: func(ctx context.Context, doc interface{}) error {
 			insertedDoc = doc.(Organization)
@@ -120,6 +120,7 @@ func TestOrganizationUpdate(t *testing.T) {
 				decode: func(dst interface{}) error {
 					dst.(*Organization).ID = "id"
 					dst.(*Organization).OwnerEmail = "old@mail.com"
+					dst.(*Organization).Revision = 2
 					dst.(*Organization).Members = []OrganizationMember{
 						{
 							ID: "user",
@@ -147,7 +148,8 @@ func TestOrganizationUpdate(t *testing.T) {
 
 	expectedDoc, _ := bson.Marshal(org)
 	expectedFilter := bson.M{
-		"id": org.ID,
+		"id":  org.ID,
+		"__v": 2,
 	}
 
 	err := UpdateOrganization(context.TODO(), org, db)
@@ -160,5 +162,243 @@ func TestOrganizationUpdate(t *testing.T) {
 
 	g.Expect(newOwnerEmailValue).To(Equal(bson.Raw(expectedDoc).Lookup("ownerEmail")))
 	g.Expect(newMemberValue).To(Equal(find.Lookup("members")))
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
 	g.Expect(updateFilter).To(Equal(expectedFilter))
 }
+
+func TestOrganizationFromV1beta1Environments(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookOrganization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	o := &Organization{}
+	o.FromV1beta1(apiSpec)
+	g.Expect(o.Environments).To(BeNil())
+
+	apiSpec.Spec.Environments = []v1beta1.GrowthbookOrganizationEnvironment{
+		{
+			Name:         "production",
+			Description:  "prod",
+			ToggleOnList: true,
+			DefaultState: true,
+			Projects:     []string{"project"},
+		},
+	}
+
+	o = &Organization{}
+	o.FromV1beta1(apiSpec)
+	g.Expect(o.Environments).To(Equal([]OrganizationEnvironment{
+		{
+			ID:           "production",
+			Description:  "prod",
+			ToggleOnList: true,
+			DefaultState: true,
+			Projects:     []string{},
+		},
+	}))
+}
+
+func TestOrganizationUpdateEnvironments(t *testing.T) {
+	g := NewWithT(t)
+
+	environments := []OrganizationEnvironment{
+		{
+			ID:       "production",
+			Projects: []string{},
+		},
+	}
+
+	existing, _ := bson.Marshal(bson.D{
+		{Key: "id", Value: "id"},
+		{Key: "settings", Value: bson.D{
+			{Key: "attributeSchema", Value: bson.A{bson.D{{Key: "property", Value: "id"}}}},
+			{Key: "environments", Value: bson.A{bson.D{{Key: "id", Value: "dev"}}}},
+		}},
+	})
+
+	var updateDoc interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					return bson.Unmarshal(existing, dst)
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc
+			existing = doc.(primitive.D)[0].Value.(bson.Raw)
+			return nil
+		},
+	}
+
+	org := Organization{
+		ID:           "id",
+		Environments: environments,
+	}
+
+	err := UpdateOrganization(context.TODO(), org, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	expectedEnvironments, _ := bson.Marshal(bson.M{"environments": environments})
+	g.Expect(updateBSON.Lookup("settings", "environments")).To(Equal(bson.Raw(expectedEnvironments).Lookup("environments")))
+	g.Expect(updateBSON.Lookup("settings", "attributeSchema", "0", "property").StringValue()).To(Equal("id"))
+
+	// Applying the same environments again does not lead to another update
+	updateDoc = nil
+	err = UpdateOrganization(context.TODO(), org, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateDoc).To(BeNil())
+}
+
+func TestOrganizationFromV1beta1CustomRoles(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookOrganization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	o := &Organization{}
+	o.FromV1beta1(apiSpec)
+	g.Expect(o.CustomRoles).To(BeNil())
+
+	apiSpec.Spec.CustomRoles = []v1beta1.GrowthbookOrganizationCustomRole{
+		{
+			ID:          "auditor",
+			Description: "read access and comments",
+			Permissions: []string{"ReadData", "Comments"},
+		},
+	}
+
+	o = &Organization{}
+	o.FromV1beta1(apiSpec)
+	g.Expect(o.CustomRoles).To(Equal([]OrganizationRole{
+		{
+			ID:          "auditor",
+			Description: "read access and comments",
+			Policies:    []string{"ReadData", "Comments"},
+		},
+	}))
+}
+
+func TestOrganizationUpdateRetainsCustomRoles(t *testing.T) {
+	g := NewWithT(t)
+
+	existing, _ := bson.Marshal(bson.D{
+		{Key: "id", Value: "id"},
+		{Key: "name", Value: "old"},
+		{Key: "customRoles", Value: bson.A{bson.D{{Key: "id", Value: "auditor"}}}},
+	})
+
+	var updateDoc interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					return bson.Unmarshal(existing, dst)
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	err := UpdateOrganization(context.TODO(), Organization{ID: "id", Name: "new"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("customRoles", "0", "id").StringValue()).To(Equal("auditor"))
+
+	err = UpdateOrganization(context.TODO(), Organization{ID: "id", Name: "new", CustomRoles: []OrganizationRole{{ID: "reviewer", Policies: []string{"ReadData"}}}}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON = updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("customRoles", "0", "id").StringValue()).To(Equal("reviewer"))
+}
+
+func TestOrganizationFromV1beta1Namespaces(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookOrganization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "foo",
+		},
+	}
+
+	o := &Organization{}
+	o.FromV1beta1(apiSpec)
+	g.Expect(o.Namespaces).To(BeNil())
+
+	apiSpec.Spec.Namespaces = []v1beta1.GrowthbookOrganizationNamespace{
+		{
+			Name:        "checkout",
+			Description: "checkout experiments",
+		},
+		{
+			Name:   "legacy",
+			Status: v1beta1.NamespaceStatusInactive,
+		},
+	}
+
+	o = &Organization{}
+	o.FromV1beta1(apiSpec)
+	g.Expect(o.Namespaces).To(Equal([]OrganizationNamespace{
+		{
+			Name:        "checkout",
+			Label:       "checkout",
+			Description: "checkout experiments",
+			Status:      "active",
+		},
+		{
+			Name:   "legacy",
+			Label:  "legacy",
+			Status: "inactive",
+		},
+	}))
+}
+
+func TestOrganizationUpdateNamespaces(t *testing.T) {
+	g := NewWithT(t)
+
+	existing, _ := bson.Marshal(bson.D{
+		{Key: "id", Value: "id"},
+		{Key: "settings", Value: bson.D{
+			{Key: "environments", Value: bson.A{bson.D{{Key: "id", Value: "dev"}}}},
+		}},
+	})
+
+	var updateDoc interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					return bson.Unmarshal(existing, dst)
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	err := UpdateOrganization(context.TODO(), Organization{
+		ID:         "id",
+		Namespaces: []OrganizationNamespace{{Name: "checkout", Label: "checkout", Status: "active"}},
+	}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("settings", "namespaces", "0", "name").StringValue()).To(Equal("checkout"))
+	g.Expect(updateBSON.Lookup("settings", "namespaces", "0", "status").StringValue()).To(Equal("active"))
+	g.Expect(updateBSON.Lookup("settings", "environments", "0", "id").StringValue()).To(Equal("dev"))
+}
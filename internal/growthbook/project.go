@@ -0,0 +1,84 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type Project struct {
+	ID           string    `bson:"id"`
+	Organization string    `bson:"organization"`
+	Name         string    `bson:"name"`
+	Description  string    `bson:"description"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	DateUpdated  time.Time `bson:"dateUpdated"`
+	Revision     int       `bson:"__v"`
+}
+
+func (p *Project) FromV1beta1(project v1beta1.GrowthbookProject) *Project {
+	p.ID = project.GetID()
+	p.Name = project.GetName()
+	p.Description = project.Spec.Description
+	return p
+}
+
+func DeleteProject(ctx context.Context, project Project, db storage.Database) error {
+	col := db.Collection("projects")
+	filter := bson.M{
+		"id":           project.ID,
+		"organization": project.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateProject(ctx context.Context, project Project, db storage.Database) error {
+	col := db.Collection("projects")
+	filter := bson.M{
+		"id":           project.ID,
+		"organization": project.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, project, documentUpdate[Project]{
+		name:  fmt.Sprintf("project %s in organization %s", project.ID, project.Organization),
+		merge: mergeFunc(mergeProject),
+		create: func(project Project) (Project, error) {
+			project.DateCreated = time.Now()
+			project.DateUpdated = project.DateCreated
+			return project, nil
+		},
+	})
+
+	return err
+}
+
+func GetProjectMeta(ctx context.Context, project Project, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("projects"), bson.M{
+		"id":           project.ID,
+		"organization": project.Organization,
+	})
+}
+
+func DiffProject(ctx context.Context, project Project, db storage.Database) ([]string, error) {
+	col := db.Collection("projects")
+	filter := bson.M{
+		"id":           project.ID,
+		"organization": project.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, project, mergeFunc(mergeProject))
+}
+
+func mergeProject(existing, project Project) Project {
+	existing.ID = project.ID
+	existing.Organization = project.Organization
+	existing.Name = project.Name
+	existing.Description = project.Description
+
+	return existing
+}
@@ -0,0 +1,159 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestProjectFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookProject{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookProjectSpec{
+			Description: "foo",
+		},
+	}
+
+	p := &Project{}
+	p.FromV1beta1(apiSpec)
+	g.Expect(p.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(p.Name).To(Equal(apiSpec.Name))
+	g.Expect(p.ID).To(Equal(apiSpec.Name))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	p.FromV1beta1(apiSpec)
+	g.Expect(p.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(p.Name).To(Equal(apiSpec.Spec.Name))
+}
+
+func TestProjectDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	project := Project{
+		ID:           "project",
+		Organization: "org",
+	}
+
+	err := DeleteProject(context.TODO(), project, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "project",
+		"organization": "org",
+	}))
+}
+
+func TestProjectCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Project
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Project)
+			return nil
+		},
+	}
+
+	project := Project{
+		ID: "project",
+	}
+
+	err := UpdateProject(context.TODO(), project, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal(project.ID))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestProjectNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Project).ID = "id"
+					dst.(*Project).Organization = "org"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	project := Project{
+		ID:           "id",
+		Organization: "org",
+	}
+
+	err := UpdateProject(context.TODO(), project, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestProjectUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Project).ID = "id"
+					dst.(*Project).Organization = "org"
+					dst.(*Project).Description = "old"
+					dst.(*Project).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	project := Project{
+		ID:           "id",
+		Organization: "org",
+		Description:  "new",
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateProject(context.TODO(), project, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("description").StringValue()).To(Equal("new"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "id",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
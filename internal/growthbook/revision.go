@@ -0,0 +1,296 @@
+package growthbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrConflict is returned if a document could not be updated because it was modified concurrently
+var ErrConflict = errors.New("document has been modified concurrently")
+
+// maxUpdateAttempts is the number of times a document is re-read and merged again if it was modified concurrently
+const maxUpdateAttempts = 5
+
+// revisionFilter returns a copy of the given filter which only matches the given revision of a document.
+// Documents without a revision key are treated as revision 0.
+func revisionFilter(filter bson.M, revision int) bson.M {
+	f := bson.M{}
+	for k, v := range filter {
+		f[k] = v
+	}
+
+	if revision == 0 {
+		f["__v"] = bson.M{"$in": bson.A{0, nil}}
+	} else {
+		f["__v"] = revision
+	}
+
+	return f
+}
+
+// DocumentMeta holds the metadata growthbook maintains for a document
+type DocumentMeta struct {
+	DateUpdated time.Time `bson:"dateUpdated"`
+	Revision    int       `bson:"__v"`
+}
+
+func findDocumentMeta(ctx context.Context, col storage.Collection, filter bson.M) (DocumentMeta, error) {
+	var meta DocumentMeta
+	result, err := col.FindOne(ctx, filter)
+	if err != nil {
+		return meta, err
+	}
+
+	return meta, result.Decode(&meta)
+}
+
+// isNotFound returns true if the error reports that no document matched the filter
+func isNotFound(err error) bool {
+	return errors.Is(err, mongo.ErrNoDocuments) || errors.Is(err, storage.ErrNoMatch)
+}
+
+// findAndMerge reads the document matching the filter and merges the desired state into it.
+// It returns the merged document together with the canonical encodings of the stored and merged documents.
+// Errors of the lookup are returned as is, isNotFound reports whether the document does not exist.
+func findAndMerge[T any](ctx context.Context, col storage.Collection, filter bson.M, desired T, merge func(existing, desired T) (T, error)) (T, []byte, []byte, error) {
+	var existing T
+	result, err := col.FindOne(ctx, filter)
+	if err != nil {
+		return existing, nil, nil, err
+	}
+
+	if err := result.Decode(&existing); err != nil {
+		return existing, nil, nil, err
+	}
+
+	existingBson, err := MarshalCanonical(existing)
+	if err != nil {
+		return existing, nil, nil, err
+	}
+
+	merged, err := merge(existing, desired)
+	if err != nil {
+		return merged, nil, nil, err
+	}
+
+	mergedBson, err := MarshalCanonical(merged)
+	if err != nil {
+		return merged, nil, nil, err
+	}
+
+	return merged, existingBson, mergedBson, nil
+}
+
+// diffDocument returns the fields of the document matching the filter which differ from the desired state.
+// No fields are returned if the document does not exist.
+func diffDocument[T any](ctx context.Context, col storage.Collection, filter bson.M, desired T, merge func(existing, desired T) (T, error)) ([]string, error) {
+	_, existingBson, mergedBson, err := findAndMerge(ctx, col, filter, desired, merge)
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return diffDocuments(existingBson, mergedBson)
+}
+
+// mergeFunc adapts a merge function which can not fail to findAndMerge
+func mergeFunc[T any](merge func(existing, desired T) T) func(existing, desired T) (T, error) {
+	return func(existing, desired T) (T, error) {
+		return merge(existing, desired), nil
+	}
+}
+
+// documentUpdate describes how updateDocument writes a document
+type documentUpdate[T any] struct {
+	// name describes the document in conflict errors
+	name string
+	// merge merges the desired state into the stored document
+	merge func(existing, desired T) (T, error)
+	// create prepares the desired state before it is inserted as a new document, nil inserts it as is
+	create func(desired T) (T, error)
+}
+
+// writeResult is the outcome of updateDocument
+type writeResult[T any] struct {
+	// created is true if the document has been inserted
+	created bool
+	// updated is true if an existing document has been changed
+	updated bool
+	// previous is the stored document before it has been updated
+	previous T
+	// document is the written document, the stored document if nothing changed
+	document T
+}
+
+// updateDocument inserts the document matching the filter or merges the desired state into it.
+// Stored documents are only written if the merge changed them. The update bumps the revision and dateUpdated (if the document has one)
+// and only applies to the revision which has been read, it is retried with the document read again if it has been modified concurrently.
+func updateDocument[T any](ctx context.Context, col storage.Collection, filter bson.M, desired T, update documentUpdate[T]) (writeResult[T], error) {
+	var result writeResult[T]
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		merged, existingBson, mergedBson, err := findAndMerge(ctx, col, filter, desired, update.merge)
+		if isNotFound(err) {
+			doc := desired
+			if update.create != nil {
+				doc, err = update.create(desired)
+				if err != nil {
+					return result, err
+				}
+			}
+
+			if err := col.InsertOne(ctx, doc); err != nil {
+				return result, err
+			}
+
+			result.created = true
+			result.document = doc
+			return result, nil
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		// The merge might modify maps of the stored document in place, the stored state is decoded again
+		var previous T
+		if err := bson.Unmarshal(existingBson, &previous); err != nil {
+			return result, err
+		}
+
+		result.previous = previous
+
+		if bytes.Equal(existingBson, mergedBson) {
+			result.document = merged
+			return result, nil
+		}
+
+		var revision int
+		if v, err := bson.Raw(existingBson).LookupErr("__v"); err == nil {
+			if r, ok := v.AsInt64OK(); ok {
+				revision = int(r)
+			}
+		}
+
+		// The update keeps the field order of the document, the canonical encoding is only used for comparison
+		mergedBson, err = bson.Marshal(merged)
+		if err != nil {
+			return result, err
+		}
+
+		var doc bson.D
+		if err := bson.Unmarshal(mergedBson, &doc); err != nil {
+			return result, err
+		}
+
+		for i := range doc {
+			switch doc[i].Key {
+			case "__v":
+				doc[i].Value = revision + 1
+			case "dateUpdated":
+				doc[i].Value = time.Now()
+			}
+		}
+
+		updateBson, err := bson.Marshal(doc)
+		if err != nil {
+			return result, err
+		}
+
+		err = col.UpdateOne(ctx, revisionFilter(filter, revision), bson.D{
+			{Key: "$set", Value: bson.Raw(updateBson)},
+		})
+		if errors.Is(err, storage.ErrNoMatch) {
+			continue
+		}
+
+		if err != nil {
+			return result, err
+		}
+
+		if err := bson.Unmarshal(updateBson, &result.document); err != nil {
+			return result, err
+		}
+
+		result.updated = true
+		return result, nil
+	}
+
+	return result, fmt.Errorf("%w: %s", ErrConflict, update.name)
+}
+
+// MarshalCanonical encodes a document with the keys of all embedded documents sorted.
+// Go maps are encoded in random order, canonical encodings of equal documents are equal byte by byte.
+func MarshalCanonical(doc interface{}) ([]byte, error) {
+	b, err := bson.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	canonical, err := canonicalDocument(bson.Raw(b))
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.Marshal(canonical)
+}
+
+func canonicalDocument(raw bson.Raw) (bson.D, error) {
+	elements, err := raw.Elements()
+	if err != nil {
+		return nil, err
+	}
+
+	doc := make(bson.D, 0, len(elements))
+	for _, element := range elements {
+		value, err := canonicalValue(element.Value())
+		if err != nil {
+			return nil, err
+		}
+
+		doc = append(doc, bson.E{Key: element.Key(), Value: value})
+	}
+
+	sort.SliceStable(doc, func(i, j int) bool {
+		return doc[i].Key < doc[j].Key
+	})
+
+	return doc, nil
+}
+
+func canonicalValue(value bson.RawValue) (interface{}, error) {
+	switch value.Type {
+	case bson.TypeEmbeddedDocument:
+		return canonicalDocument(value.Document())
+	case bson.TypeArray:
+		values, err := value.Array().Values()
+		if err != nil {
+			return nil, err
+		}
+
+		array := make(bson.A, 0, len(values))
+		for _, v := range values {
+			item, err := canonicalValue(v)
+			if err != nil {
+				return nil, err
+			}
+
+			array = append(array, item)
+		}
+
+		return array, nil
+	default:
+		return value, nil
+	}
+}
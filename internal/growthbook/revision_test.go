@@ -0,0 +1,153 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestRevisionFilter(t *testing.T) {
+	g := NewWithT(t)
+
+	filter := bson.M{
+		"id": "id",
+	}
+
+	g.Expect(revisionFilter(filter, 3)).To(Equal(bson.M{
+		"id":  "id",
+		"__v": 3,
+	}))
+
+	g.Expect(revisionFilter(filter, 0)).To(Equal(bson.M{
+		"id":  "id",
+		"__v": bson.M{"$in": bson.A{0, nil}},
+	}))
+
+	g.Expect(filter).To(Equal(bson.M{
+		"id": "id",
+	}))
+}
+
+func TestFeatureMeta(t *testing.T) {
+	g := NewWithT(t)
+
+	dateUpdated := time.Now()
+	var findFilter bson.M
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			findFilter = filter.(bson.M)
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*DocumentMeta).DateUpdated = dateUpdated
+					dst.(*DocumentMeta).Revision = 3
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	meta, err := GetFeatureMeta(context.TODO(), Feature{ID: "id", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(meta).To(Equal(DocumentMeta{DateUpdated: dateUpdated, Revision: 3}))
+	g.Expect(findFilter).To(Equal(bson.M{
+		"id":           "id",
+		"organization": "org",
+	}))
+}
+
+type testDocument struct {
+	ID          string    `bson:"id"`
+	Value       string    `bson:"value"`
+	DateUpdated time.Time `bson:"dateUpdated"`
+	Revision    int       `bson:"__v"`
+}
+
+func mergeTestDocument(existing, desired testDocument) testDocument {
+	existing.Value = desired.Value
+	return existing
+}
+
+func TestUpdateDocument(t *testing.T) {
+	g := NewWithT(t)
+
+	var updates int
+	var updateFilter interface{}
+	var updateDoc interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*testDocument).ID = "id"
+					dst.(*testDocument).Value = "old"
+					dst.(*testDocument).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updates++
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	update := documentUpdate[testDocument]{
+		name:  "document id",
+		merge: mergeFunc(mergeTestDocument),
+	}
+
+	result, err := updateDocument(context.TODO(), db.Collection("test"), bson.M{"id": "id"}, testDocument{ID: "id", Value: "old"}, update)
+	g.Expect(err).To(BeNil())
+	g.Expect(updates).To(Equal(0))
+	g.Expect(result.updated).To(BeFalse())
+	g.Expect(result.created).To(BeFalse())
+
+	beforeUpdate := time.Now()
+	result, err = updateDocument(context.TODO(), db.Collection("test"), bson.M{"id": "id"}, testDocument{ID: "id", Value: "new"}, update)
+	g.Expect(err).To(BeNil())
+	g.Expect(updates).To(Equal(1))
+	g.Expect(result.updated).To(BeTrue())
+	g.Expect(result.previous.Value).To(Equal("old"))
+	g.Expect(result.document.Value).To(Equal("new"))
+	g.Expect(result.document.Revision).To(Equal(3))
+	g.Expect(result.document.DateUpdated).NotTo(BeTemporally("<", beforeUpdate.Truncate(time.Millisecond)))
+	g.Expect(updateFilter).To(Equal(bson.M{"id": "id", "__v": 2}))
+
+	updateBSON := updateDoc.(bson.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateBSON.Lookup("value").StringValue()).To(Equal("new"))
+
+	db.UpdateOne = func(ctx context.Context, filter, doc interface{}) error {
+		updates++
+		return storage.ErrNoMatch
+	}
+
+	updates = 0
+	_, err = updateDocument(context.TODO(), db.Collection("test"), bson.M{"id": "id"}, testDocument{ID: "id", Value: "new"}, update)
+	g.Expect(err).To(MatchError(ErrConflict))
+	g.Expect(err.Error()).To(ContainSubstring("document id"))
+	g.Expect(updates).To(Equal(maxUpdateAttempts))
+}
+
+func TestMarshalCanonical(t *testing.T) {
+	g := NewWithT(t)
+
+	a, err := MarshalCanonical(bson.M{"b": bson.M{"y": 1, "x": bson.A{bson.M{"d": 1, "c": 2}}}, "a": "a"})
+	g.Expect(err).To(BeNil())
+
+	b, err := MarshalCanonical(bson.D{{Key: "a", Value: "a"}, {Key: "b", Value: bson.D{{Key: "x", Value: bson.A{bson.D{{Key: "c", Value: 2}, {Key: "d", Value: 1}}}}, {Key: "y", Value: 1}}}})
+	g.Expect(err).To(BeNil())
+	g.Expect(a).To(Equal(b))
+
+	// Arrays keep their order
+	c, err := MarshalCanonical(bson.M{"a": bson.A{1, 2}})
+	g.Expect(err).To(BeNil())
+	d, err := MarshalCanonical(bson.M{"a": bson.A{2, 1}})
+	g.Expect(err).To(BeNil())
+	g.Expect(c).NotTo(Equal(d))
+}
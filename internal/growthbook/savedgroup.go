@@ -0,0 +1,125 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type SavedGroupType string
+
+var (
+	SavedGroupTypeCondition SavedGroupType = "condition"
+	SavedGroupTypeList      SavedGroupType = "list"
+)
+
+type SavedGroup struct {
+	ID           string         `bson:"id"`
+	Organization string         `bson:"organization"`
+	GroupName    string         `bson:"groupName"`
+	Owner        string         `bson:"owner"`
+	Type         SavedGroupType `bson:"type"`
+	Condition    string         `bson:"condition"`
+	AttributeKey string         `bson:"attributeKey"`
+	Values       []string       `bson:"values"`
+	Description  string         `bson:"description"`
+	Projects     []string       `bson:"projects"`
+	DateCreated  time.Time      `bson:"dateCreated"`
+	DateUpdated  time.Time      `bson:"dateUpdated"`
+	Revision     int            `bson:"__v"`
+}
+
+// FromV1beta1 converts a GrowthbookSavedGroup, values from a ConfigMap and projects need to be resolved by the caller
+func (s *SavedGroup) FromV1beta1(savedGroup v1beta1.GrowthbookSavedGroup) *SavedGroup {
+	s.ID = savedGroup.GetID()
+	s.GroupName = savedGroup.GetName()
+	s.Owner = savedGroup.Spec.Owner
+	s.Type = SavedGroupType(savedGroup.Spec.Type)
+	s.Condition = savedGroup.Spec.Condition
+	s.AttributeKey = savedGroup.Spec.AttributeKey
+	s.Values = savedGroup.Spec.Values
+	s.Description = savedGroup.Spec.Description
+	s.Projects = []string{}
+
+	if s.Values == nil {
+		s.Values = []string{}
+	}
+
+	return s
+}
+
+func DeleteSavedGroup(ctx context.Context, savedGroup SavedGroup, db storage.Database) error {
+	col := db.Collection("savedgroups")
+	filter := bson.M{
+		"id":           savedGroup.ID,
+		"organization": savedGroup.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateSavedGroup(ctx context.Context, savedGroup SavedGroup, db storage.Database) error {
+	col := db.Collection("savedgroups")
+	filter := bson.M{
+		"id":           savedGroup.ID,
+		"organization": savedGroup.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, savedGroup, documentUpdate[SavedGroup]{
+		name:  fmt.Sprintf("saved group %s in organization %s", savedGroup.ID, savedGroup.Organization),
+		merge: mergeFunc(mergeSavedGroup),
+		create: func(savedGroup SavedGroup) (SavedGroup, error) {
+			savedGroup.DateCreated = time.Now()
+			savedGroup.DateUpdated = savedGroup.DateCreated
+			return savedGroup, nil
+		},
+	})
+
+	return err
+}
+
+func GetSavedGroupMeta(ctx context.Context, savedGroup SavedGroup, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("savedgroups"), bson.M{
+		"id":           savedGroup.ID,
+		"organization": savedGroup.Organization,
+	})
+}
+
+// SavedGroupExists returns true if the saved group document exists in the organization
+func SavedGroupExists(ctx context.Context, savedGroup SavedGroup, db storage.Database) (bool, error) {
+	_, err := GetSavedGroupMeta(ctx, savedGroup, db)
+	if isNotFound(err) {
+		return false, nil
+	}
+
+	return err == nil, err
+}
+
+func DiffSavedGroup(ctx context.Context, savedGroup SavedGroup, db storage.Database) ([]string, error) {
+	col := db.Collection("savedgroups")
+	filter := bson.M{
+		"id":           savedGroup.ID,
+		"organization": savedGroup.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, savedGroup, mergeFunc(mergeSavedGroup))
+}
+
+func mergeSavedGroup(existing, savedGroup SavedGroup) SavedGroup {
+	existing.ID = savedGroup.ID
+	existing.Organization = savedGroup.Organization
+	existing.GroupName = savedGroup.GroupName
+	existing.Owner = savedGroup.Owner
+	existing.Type = savedGroup.Type
+	existing.Condition = savedGroup.Condition
+	existing.AttributeKey = savedGroup.AttributeKey
+	existing.Values = savedGroup.Values
+	existing.Description = savedGroup.Description
+	existing.Projects = savedGroup.Projects
+
+	return existing
+}
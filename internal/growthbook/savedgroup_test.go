@@ -0,0 +1,211 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSavedGroupFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookSavedGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookSavedGroupSpec{
+			Description:  "foo",
+			Owner:        "owner",
+			Type:         v1beta1.SavedGroupTypeList,
+			AttributeKey: "id",
+			Values:       []string{"a", "b"},
+			Projects:     []string{"project"},
+		},
+	}
+
+	s := &SavedGroup{}
+	s.FromV1beta1(apiSpec)
+	g.Expect(s.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(s.Owner).To(Equal(apiSpec.Spec.Owner))
+	g.Expect(s.Type).To(Equal(SavedGroupTypeList))
+	g.Expect(s.AttributeKey).To(Equal(apiSpec.Spec.AttributeKey))
+	g.Expect(s.Values).To(Equal(apiSpec.Spec.Values))
+	g.Expect(s.Projects).To(Equal([]string{}))
+	g.Expect(s.GroupName).To(Equal(apiSpec.Name))
+	g.Expect(s.ID).To(Equal(apiSpec.Name))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Values = nil
+	s.FromV1beta1(apiSpec)
+	g.Expect(s.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(s.GroupName).To(Equal(apiSpec.Spec.Name))
+	g.Expect(s.Values).To(Equal([]string{}))
+}
+
+func TestSavedGroupDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	savedGroup := SavedGroup{
+		ID:           "savedGroup",
+		Organization: "org",
+	}
+
+	err := DeleteSavedGroup(context.TODO(), savedGroup, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "savedGroup",
+		"organization": "org",
+	}))
+}
+
+func TestSavedGroupCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc SavedGroup
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(SavedGroup)
+			return nil
+		},
+	}
+
+	savedGroup := SavedGroup{
+		ID: "savedGroup",
+	}
+
+	err := UpdateSavedGroup(context.TODO(), savedGroup, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal(savedGroup.ID))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestSavedGroupNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*SavedGroup).ID = "id"
+					dst.(*SavedGroup).Organization = "org"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	savedGroup := SavedGroup{
+		ID:           "id",
+		Organization: "org",
+	}
+
+	err := UpdateSavedGroup(context.TODO(), savedGroup, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestSavedGroupUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*SavedGroup).ID = "id"
+					dst.(*SavedGroup).Organization = "org"
+					dst.(*SavedGroup).Description = "old"
+					dst.(*SavedGroup).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	savedGroup := SavedGroup{
+		ID:           "id",
+		Organization: "org",
+		Description:  "new",
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateSavedGroup(context.TODO(), savedGroup, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("description").StringValue()).To(Equal("new"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "id",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestSavedGroupExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var findFilter interface{}
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			findFilter = filter
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	exists, err := SavedGroupExists(context.TODO(), SavedGroup{ID: "id", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(exists).To(BeTrue())
+	g.Expect(findFilter).To(Equal(bson.M{
+		"id":           "id",
+		"organization": "org",
+	}))
+
+	db.FindOne = func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+		return nil, mongo.ErrNoDocuments
+	}
+
+	exists, err = SavedGroupExists(context.TODO(), SavedGroup{ID: "id", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(exists).To(BeFalse())
+
+	db.FindOne = func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+		return nil, errors.New("connection refused")
+	}
+
+	_, err = SavedGroupExists(context.TODO(), SavedGroup{ID: "id", Organization: "org"}, db)
+	g.Expect(err).NotTo(BeNil())
+}
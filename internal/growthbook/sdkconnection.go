@@ -1,10 +1,10 @@
 package growthbook
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/base64"
+	"fmt"
 	"strings"
 	"time"
 
@@ -33,6 +33,8 @@ type SDKConnection struct {
 }
 
 type SDKConnectionProxy struct {
+	Enabled    bool   `bson:"enabled"`
+	Host       string `bson:"host"`
 	SigningKey string `bson:"signingKey"`
 }
 
@@ -42,7 +44,6 @@ func (s *SDKConnection) FromV1beta1(client v1beta1.GrowthbookClient) *SDKConnect
 	s.Languages = client.Spec.Languages
 	s.Environment = client.Spec.Environment
 	s.EncryptPayload = client.Spec.EncryptPayload
-	s.Project = client.Spec.Project
 	s.IncludeVisualExperiments = client.Spec.IncludeVisualExperiments
 	s.IncludeDraftExperiments = client.Spec.IncludeDraftExperiments
 	s.IncludeExperimentNames = client.Spec.IncludeExperimentNames
@@ -57,65 +58,87 @@ func (s *SDKConnection) FromV1beta1(client v1beta1.GrowthbookClient) *SDKConnect
 func DeleteSDKConnection(ctx context.Context, sdkconnection SDKConnection, db storage.Database) error {
 	col := db.Collection("sdkconnections")
 	filter := bson.M{
-		"id": sdkconnection.ID,
+		"id":           sdkconnection.ID,
+		"organization": sdkconnection.Organization,
 	}
 
 	return col.DeleteOne(ctx, filter)
 }
 
-func UpdateSDKConnection(ctx context.Context, sdkconnection SDKConnection, db storage.Database) error {
+func FindSDKConnections(ctx context.Context, organization string, db storage.Database) ([]SDKConnection, error) {
 	col := db.Collection("sdkconnections")
 	filter := bson.M{
-		"id": sdkconnection.ID,
+		"organization": organization,
 	}
 
-	clearPayloadCache := func() error {
-		//growthbook caches the response payload, clear it
-		filter = bson.M{
-			"environment":  sdkconnection.Environment,
-			"organization": sdkconnection.Organization,
-		}
-
-		return db.Collection("sdkpayloads").DeleteMany(ctx, filter)
+	cursor, err := col.Find(ctx, filter)
+	if err != nil {
+		return nil, err
 	}
 
-	var existing SDKConnection
-	result, err := col.FindOne(ctx, filter)
-
-	if err != nil {
-		sdkconnection.DateCreated = time.Now()
-		sdkconnection.DateUpdated = sdkconnection.DateCreated
+	var sdkconnections []SDKConnection
+	return sdkconnections, cursor.All(ctx, &sdkconnections)
+}
 
-		encryptionKey, err := generateKey("", 32)
-		if err != nil {
-			return err
-		}
+func UpdateSDKConnection(ctx context.Context, sdkconnection SDKConnection, db storage.Database, changes *ChangeSet) error {
+	col := db.Collection("sdkconnections")
+	filter := bson.M{
+		"id":           sdkconnection.ID,
+		"organization": sdkconnection.Organization,
+	}
 
-		signingKey, err := generateKey("", 32)
-		if err != nil {
-			return err
-		}
+	result, err := updateDocument(ctx, col, filter, sdkconnection, documentUpdate[SDKConnection]{
+		name:  fmt.Sprintf("sdk connection %s in organization %s", sdkconnection.ID, sdkconnection.Organization),
+		merge: mergeFunc(mergeSDKConnection),
+		create: func(sdkconnection SDKConnection) (SDKConnection, error) {
+			sdkconnection.DateCreated = time.Now()
+			sdkconnection.DateUpdated = sdkconnection.DateCreated
+
+			encryptionKey, err := generateKey("", 32)
+			if err != nil {
+				return sdkconnection, err
+			}
+
+			signingKey, err := generateKey("", 32)
+			if err != nil {
+				return sdkconnection, err
+			}
+
+			sdkconnection.EncryptionKey = encryptionKey
+			sdkconnection.Proxy.SigningKey = signingKey
+			return sdkconnection, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
 
-		sdkconnection.EncryptionKey = encryptionKey
-		sdkconnection.Proxy.SigningKey = signingKey
+	// growthbook caches the response payload, clear it
+	if result.created || result.updated {
+		changes.Invalidate(sdkconnection.Organization, sdkconnection.Environment)
+	}
 
-		err = col.InsertOne(ctx, sdkconnection)
-		if err != nil {
-			return err
-		}
+	return nil
+}
 
-		return clearPayloadCache()
-	}
+func GetSDKConnectionMeta(ctx context.Context, sdkconnection SDKConnection, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("sdkconnections"), bson.M{
+		"id":           sdkconnection.ID,
+		"organization": sdkconnection.Organization,
+	})
+}
 
-	if err := result.Decode(&existing); err != nil {
-		return err
+func DiffSDKConnection(ctx context.Context, sdkconnection SDKConnection, db storage.Database) ([]string, error) {
+	col := db.Collection("sdkconnections")
+	filter := bson.M{
+		"id":           sdkconnection.ID,
+		"organization": sdkconnection.Organization,
 	}
 
-	existingBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
-	}
+	return diffDocument(ctx, col, filter, sdkconnection, mergeFunc(mergeSDKConnection))
+}
 
+func mergeSDKConnection(existing, sdkconnection SDKConnection) SDKConnection {
 	existing.ID = sdkconnection.ID
 	existing.Key = sdkconnection.Key
 	existing.Languages = sdkconnection.Languages
@@ -128,31 +151,7 @@ func UpdateSDKConnection(ctx context.Context, sdkconnection SDKConnection, db st
 	existing.IncludeDraftExperiments = sdkconnection.IncludeDraftExperiments
 	existing.IncludeDraftExperiments = sdkconnection.IncludeDraftExperiments
 
-	updateBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
-	}
-
-	if bytes.Equal(existingBson, updateBson) {
-		return nil
-	}
-
-	existing.DateUpdated = time.Now()
-	updateBson, err = bson.Marshal(existing)
-	if err != nil {
-		return err
-	}
-
-	update := bson.D{
-		{Key: "$set", Value: bson.Raw(updateBson)},
-	}
-
-	err = col.UpdateOne(ctx, filter, update)
-	if err != nil {
-		return err
-	}
-
-	return clearPayloadCache()
+	return existing
 }
 
 func generateKey(prefix string, n int) (string, error) {
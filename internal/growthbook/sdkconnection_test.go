@@ -2,7 +2,6 @@ package growthbook
 
 import (
 	"context"
-	"errors"
 	"testing"
 	"time"
 
@@ -11,6 +10,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -26,7 +26,6 @@ func TestSDKConnectionFromV1beta1(t *testing.T) {
 			Languages:                []string{"go"},
 			Environment:              "test",
 			EncryptPayload:           true,
-			Project:                  "test",
 			IncludeVisualExperiments: true,
 			IncludeDraftExperiments:  true,
 			IncludeExperimentNames:   true,
@@ -38,7 +37,6 @@ func TestSDKConnectionFromV1beta1(t *testing.T) {
 	g.Expect(f.Languages).To(Equal(apiSpec.Spec.Languages))
 	g.Expect(f.Environment).To(Equal(apiSpec.Spec.Environment))
 	g.Expect(f.EncryptPayload).To(Equal(apiSpec.Spec.EncryptPayload))
-	g.Expect(f.Project).To(Equal(apiSpec.Spec.Project))
 	g.Expect(f.IncludeVisualExperiments).To(Equal(apiSpec.Spec.IncludeVisualExperiments))
 	g.Expect(f.IncludeDraftExperiments).To(Equal(apiSpec.Spec.IncludeDraftExperiments))
 	g.Expect(f.IncludeExperimentNames).To(Equal(apiSpec.Spec.IncludeExperimentNames))
@@ -64,13 +62,15 @@ func TestSDKConnectionDelete(t *testing.T) {
 	}
 
 	sdkconnection := SDKConnection{
-		ID: "sdkconnection",
+		ID:           "sdkconnection",
+		Organization: "org",
 	}
 
 	err := DeleteSDKConnection(context.TODO(), sdkconnection, db)
 	g.Expect(err).To(BeNil())
 	g.Expect(deleteFilter).To(Equal(bson.M{
-		"id": "sdkconnection",
+		"id":           "sdkconnection",
+		"organization": "org",
 	}))
 }
 
@@ -80,23 +80,25 @@ func TestSDKConnectionCreateIfNotExists(t *testing.T) {
 	var insertedDoc SDKConnection
 	db := &MockDatabase{
 		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
-			return nil, errors.New("does not exists")
+			return nil, mongo.ErrNoDocuments
 		},
 		InsertOne: func(ctx context.Context, doc interface{}) error {
 			insertedDoc = doc.(SDKConnection)
 			return nil
 		},
-		DeleteMany: func(ctx context.Context, filter interface{}) error {
-			return nil
-		},
 	}
 
 	SDKConnection := SDKConnection{
 		ID: "SDKConnection",
 	}
 
-	err := UpdateSDKConnection(context.TODO(), SDKConnection, db)
+	SDKConnection.Organization = "org"
+	SDKConnection.Environment = "dev"
+
+	changes := &ChangeSet{}
+	err := UpdateSDKConnection(context.TODO(), SDKConnection, db, changes)
 	g.Expect(err).To(BeNil())
+	g.Expect(changes.Environments()).To(Equal(map[string][]string{"org": {"dev"}}))
 	g.Expect(insertedDoc.ID).To(Equal(SDKConnection.ID))
 	g.Expect(insertedDoc.EncryptionKey).To(Not(Equal("")))
 	g.Expect(insertedDoc.Proxy.SigningKey).To(Not(Equal("")))
@@ -120,7 +122,7 @@ func TestSDKConnectionNoUpdate(t *testing.T) {
 		ID: "id",
 	}
 
-	err := UpdateSDKConnection(context.TODO(), sdkconnection, db)
+	err := UpdateSDKConnection(context.TODO(), sdkconnection, db, &ChangeSet{})
 	g.Expect(err).To(BeNil())
 }
 
@@ -138,6 +140,7 @@ func TestSDKConnectionUpdate(t *testing.T) {
 					dst.(*SDKConnection).ID = "id"
 					dst.(*SDKConnection).EncryptionKey = "key-x"
 					dst.(*SDKConnection).Proxy.SigningKey = "key-y"
+					dst.(*SDKConnection).Revision = 2
 
 					f, _ := bson.Marshal(dst)
 					find = f
@@ -151,23 +154,23 @@ func TestSDKConnectionUpdate(t *testing.T) {
 			updateDoc = doc
 			return nil
 		},
-		DeleteMany: func(ctx context.Context, filter interface{}) error {
-			return nil
-		},
 	}
 
 	sdkconnection := SDKConnection{
 		ID:             "id",
+		Organization:   "org",
 		EncryptPayload: true,
 	}
 
 	expectedDoc, _ := bson.Marshal(sdkconnection)
 	expectedFilter := bson.M{
-		"id": sdkconnection.ID,
+		"id":           sdkconnection.ID,
+		"organization": sdkconnection.Organization,
+		"__v":          2,
 	}
 
 	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
-	err := UpdateSDKConnection(context.TODO(), sdkconnection, db)
+	err := UpdateSDKConnection(context.TODO(), sdkconnection, db, &ChangeSet{})
 	g.Expect(err).To(BeNil())
 
 	updateDocSet := updateDoc.(primitive.D)
@@ -183,6 +186,7 @@ func TestSDKConnectionUpdate(t *testing.T) {
 
 	newProxySigningKey := updateBSON.Lookup("proxy.signingKey")
 	g.Expect(newProxySigningKey).To(Equal(find.Lookup("proxy.signingKey")))
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
 
 	g.Expect(dateUpdated.After(beforeUpdate)).To(BeTrue())
 	g.Expect(updateFilter).To(Equal(expectedFilter))
@@ -0,0 +1,104 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// Segment is a group of users experiment results can be restricted to
+type Segment struct {
+	ID           string    `bson:"id"`
+	Organization string    `bson:"organization"`
+	Owner        string    `bson:"owner"`
+	DataSource   string    `bson:"datasource"`
+	UserIDType   string    `bson:"userIdType"`
+	Name         string    `bson:"name"`
+	Description  string    `bson:"description"`
+	SQL          string    `bson:"sql"`
+	DateCreated  time.Time `bson:"dateCreated"`
+	DateUpdated  time.Time `bson:"dateUpdated"`
+	Revision     int       `bson:"__v"`
+}
+
+func (s *Segment) FromV1beta1(segment v1beta1.GrowthbookSegment) *Segment {
+	s.ID = segment.GetID()
+	s.Name = segment.GetName()
+	s.Description = segment.Spec.Description
+	s.Owner = segment.Spec.Owner
+	s.UserIDType = segment.Spec.UserIDType
+	s.SQL = segment.Spec.SQL
+
+	// The data source is referenced by resource name and needs to be resolved by the caller
+	s.DataSource = segment.Spec.DataSource
+
+	if s.UserIDType == "" {
+		s.UserIDType = "user_id"
+	}
+
+	return s
+}
+
+func DeleteSegment(ctx context.Context, segment Segment, db storage.Database) error {
+	col := db.Collection("segments")
+	filter := bson.M{
+		"id":           segment.ID,
+		"organization": segment.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateSegment(ctx context.Context, segment Segment, db storage.Database) error {
+	col := db.Collection("segments")
+	filter := bson.M{
+		"id":           segment.ID,
+		"organization": segment.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, segment, documentUpdate[Segment]{
+		name:  fmt.Sprintf("segment %s in organization %s", segment.ID, segment.Organization),
+		merge: mergeFunc(mergeSegment),
+		create: func(segment Segment) (Segment, error) {
+			segment.DateCreated = time.Now()
+			segment.DateUpdated = segment.DateCreated
+			return segment, nil
+		},
+	})
+
+	return err
+}
+
+func GetSegmentMeta(ctx context.Context, segment Segment, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("segments"), bson.M{
+		"id":           segment.ID,
+		"organization": segment.Organization,
+	})
+}
+
+func DiffSegment(ctx context.Context, segment Segment, db storage.Database) ([]string, error) {
+	col := db.Collection("segments")
+	filter := bson.M{
+		"id":           segment.ID,
+		"organization": segment.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, segment, mergeFunc(mergeSegment))
+}
+
+func mergeSegment(existing, segment Segment) Segment {
+	existing.ID = segment.ID
+	existing.Organization = segment.Organization
+	existing.Owner = segment.Owner
+	existing.DataSource = segment.DataSource
+	existing.UserIDType = segment.UserIDType
+	existing.Name = segment.Name
+	existing.Description = segment.Description
+	existing.SQL = segment.SQL
+
+	return existing
+}
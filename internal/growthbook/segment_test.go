@@ -0,0 +1,170 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSegmentFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookSegment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookSegmentSpec{
+			Description: "foo",
+			Owner:       "u_owner",
+			DataSource:  "warehouse",
+			SQL:         "SELECT * FROM users",
+		},
+	}
+
+	s := &Segment{}
+	s.FromV1beta1(apiSpec)
+	g.Expect(s.ID).To(Equal(apiSpec.Name))
+	g.Expect(s.Name).To(Equal(apiSpec.Name))
+	g.Expect(s.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(s.Owner).To(Equal("u_owner"))
+	g.Expect(s.DataSource).To(Equal("warehouse"))
+	g.Expect(s.UserIDType).To(Equal("user_id"))
+	g.Expect(s.SQL).To(Equal(apiSpec.Spec.SQL))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.UserIDType = "anonymous_id"
+	s.FromV1beta1(apiSpec)
+	g.Expect(s.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(s.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(s.UserIDType).To(Equal("anonymous_id"))
+}
+
+func TestSegmentDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteSegment(context.TODO(), Segment{ID: "segment", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "segment",
+		"organization": "org",
+	}))
+}
+
+func TestSegmentCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Segment
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Segment)
+			return nil
+		},
+	}
+
+	err := UpdateSegment(context.TODO(), Segment{ID: "segment", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("segment"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestSegmentNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Segment).ID = "segment"
+					dst.(*Segment).Organization = "org"
+					dst.(*Segment).SQL = "SELECT * FROM customers"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateSegment(context.TODO(), Segment{ID: "segment", Organization: "org", SQL: "SELECT * FROM customers"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestSegmentUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Segment).ID = "segment"
+					dst.(*Segment).Organization = "org"
+					dst.(*Segment).SQL = "SELECT * FROM users"
+					dst.(*Segment).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateSegment(context.TODO(), Segment{ID: "segment", Organization: "org", SQL: "SELECT * FROM customers"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("sql").StringValue()).To(Equal("SELECT * FROM customers"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "segment",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestSegmentDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Segment).ID = "segment"
+					dst.(*Segment).Organization = "org"
+					dst.(*Segment).SQL = "SELECT * FROM users"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffSegment(context.TODO(), Segment{ID: "segment", Organization: "org", SQL: "SELECT * FROM customers"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"sql"}))
+}
@@ -20,6 +20,7 @@ func (d *MockDisconnect) Disconnect(ctx context.Context) error {
 }
 
 type MockDatabase struct {
+	Find       func(ctx context.Context, filter interface{}) (storage.Cursor, error)
 	FindOne    func(ctx context.Context, filter interface{}) (storage.Decoder, error)
 	DeleteOne  func(ctx context.Context, filter interface{}) error
 	InsertOne  func(ctx context.Context, doc interface{}) error
@@ -33,6 +34,10 @@ func (d *MockDatabase) Collection(collName string) storage.Collection {
 	}
 }
 
+func (d *MockDatabase) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
 type MockResult struct {
 	decode func(v interface{}) error
 }
@@ -45,10 +50,30 @@ func (r *MockResult) Decode(dst interface{}) error {
 	return nil
 }
 
+type MockCursor struct {
+	all func(results interface{}) error
+}
+
+func (c *MockCursor) All(ctx context.Context, results interface{}) error {
+	if c.all != nil {
+		return c.all(results)
+	}
+
+	return nil
+}
+
 type MockCollection struct {
 	db *MockDatabase
 }
 
+func (c *MockCollection) Find(ctx context.Context, filter interface{}) (storage.Cursor, error) {
+	if c.db.Find == nil {
+		return nil, errors.New("no mock func for find provided")
+	}
+
+	return c.db.Find(ctx, filter)
+}
+
 func (c *MockCollection) FindOne(ctx context.Context, filter interface{}) (storage.Decoder, error) {
 	if c.db.FindOne == nil {
 		return nil, errors.New("no mock func for findOne provided")
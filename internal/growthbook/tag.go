@@ -0,0 +1,207 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+	"golang.org/x/exp/slices"
+)
+
+// Tag is an entry of the tags document of an organization
+type Tag struct {
+	Organization string `bson:"-"`
+	ID           string `bson:"-"`
+	Color        string `bson:"color"`
+	Description  string `bson:"description"`
+
+	// Extra retains fields which are not managed by the controller
+	Extra bson.M `bson:",inline"`
+}
+
+// organizationTags is the single tags document growthbook maintains per organization
+type organizationTags struct {
+	Organization string         `bson:"organization"`
+	Tags         []string       `bson:"tags"`
+	Settings     map[string]Tag `bson:"settings"`
+	Revision     int            `bson:"__v"`
+}
+
+// errTagsNotFound is returned if the tags document of an organization does not exist
+var errTagsNotFound = errors.New("tags not found")
+
+func (t *Tag) FromV1beta1(tag v1beta1.GrowthbookTag) *Tag {
+	t.ID = tag.GetTag()
+	t.Color = tag.Spec.Color
+	t.Description = tag.Spec.Description
+	return t
+}
+
+func DeleteTag(ctx context.Context, tag Tag, db storage.Database) error {
+	err := updateTags(ctx, tag, db, func(tags *organizationTags) {
+		tags.Tags = slices.DeleteFunc(tags.Tags, func(t string) bool {
+			return t == tag.ID
+		})
+
+		delete(tags.Settings, tag.ID)
+	})
+
+	if errors.Is(err, errTagsNotFound) {
+		return nil
+	}
+
+	return err
+}
+
+func UpdateTag(ctx context.Context, tag Tag, db storage.Database) error {
+	err := updateTags(ctx, tag, db, func(tags *organizationTags) {
+		if !slices.Contains(tags.Tags, tag.ID) {
+			tags.Tags = append(tags.Tags, tag.ID)
+		}
+
+		tags.Settings[tag.ID] = mergeTag(tags.Settings[tag.ID], tag)
+	})
+
+	if !errors.Is(err, errTagsNotFound) {
+		return err
+	}
+
+	return db.Collection("tags").InsertOne(ctx, organizationTags{
+		Organization: tag.Organization,
+		Tags:         []string{tag.ID},
+		Settings: map[string]Tag{
+			tag.ID: mergeTag(Tag{}, tag),
+		},
+	})
+}
+
+// updateTags applies a change to the tags document of an organization, tags which are not managed are retained
+func updateTags(ctx context.Context, tag Tag, db storage.Database, change func(tags *organizationTags)) error {
+	col := db.Collection("tags")
+	filter := bson.M{
+		"organization": tag.Organization,
+	}
+
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		var existing organizationTags
+		result, err := col.FindOne(ctx, filter)
+		if isNotFound(err) {
+			return fmt.Errorf("%w: %s", errTagsNotFound, tag.Organization)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if err := result.Decode(&existing); err != nil {
+			return err
+		}
+
+		if existing.Settings == nil {
+			existing.Settings = make(map[string]Tag)
+		}
+
+		existingBson, err := bson.Marshal(existing)
+		if err != nil {
+			return err
+		}
+
+		updated := organizationTags{
+			Organization: existing.Organization,
+			Tags:         slices.Clone(existing.Tags),
+			Settings:     make(map[string]Tag, len(existing.Settings)),
+			Revision:     existing.Revision,
+		}
+
+		for k, v := range existing.Settings {
+			updated.Settings[k] = v
+		}
+
+		change(&updated)
+
+		updateBson, err := bson.Marshal(updated)
+		if err != nil {
+			return err
+		}
+
+		fields, err := diffDocuments(existingBson, updateBson)
+		if err != nil || len(fields) == 0 {
+			return err
+		}
+
+		if updated.Tags == nil {
+			updated.Tags = []string{}
+		}
+
+		update := bson.D{
+			{Key: "$set", Value: bson.M{
+				"tags":     updated.Tags,
+				"settings": updated.Settings,
+				"__v":      existing.Revision + 1,
+			}},
+		}
+
+		err = col.UpdateOne(ctx, revisionFilter(filter, existing.Revision), update)
+		if errors.Is(err, storage.ErrNoMatch) {
+			continue
+		}
+
+		return err
+	}
+
+	return fmt.Errorf("%w: tag %s in organization %s", ErrConflict, tag.ID, tag.Organization)
+}
+
+func GetTagMeta(ctx context.Context, tag Tag, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("tags"), bson.M{
+		"organization": tag.Organization,
+	})
+}
+
+func DiffTag(ctx context.Context, tag Tag, db storage.Database) ([]string, error) {
+	col := db.Collection("tags")
+	filter := bson.M{
+		"organization": tag.Organization,
+	}
+
+	var existing organizationTags
+	result, err := col.FindOne(ctx, filter)
+	if isNotFound(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := result.Decode(&existing); err != nil {
+		return nil, err
+	}
+
+	settings, ok := existing.Settings[tag.ID]
+	if !ok || !slices.Contains(existing.Tags, tag.ID) {
+		return nil, nil
+	}
+
+	existingBson, err := bson.Marshal(settings)
+	if err != nil {
+		return nil, err
+	}
+
+	updateBson, err := bson.Marshal(mergeTag(settings, tag))
+	if err != nil {
+		return nil, err
+	}
+
+	return diffDocuments(existingBson, updateBson)
+}
+
+func mergeTag(existing, tag Tag) Tag {
+	existing.Color = tag.Color
+	existing.Description = tag.Description
+
+	return existing
+}
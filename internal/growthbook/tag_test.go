@@ -0,0 +1,223 @@
+package growthbook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTagFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookTag{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "checkout",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookTagSpec{
+			Color:       "#ff0000",
+			Description: "foo",
+		},
+	}
+
+	tag := &Tag{}
+	tag.FromV1beta1(apiSpec)
+	g.Expect(tag.ID).To(Equal(apiSpec.Name))
+	g.Expect(tag.Color).To(Equal(apiSpec.Spec.Color))
+	g.Expect(tag.Description).To(Equal(apiSpec.Spec.Description))
+
+	apiSpec.Spec.Name = "custom"
+	tag.FromV1beta1(apiSpec)
+	g.Expect(tag.ID).To(Equal(apiSpec.Spec.Name))
+}
+
+func tagsResult(revision int, tags []string, settings bson.M) *MockResult {
+	return &MockResult{
+		decode: func(dst interface{}) error {
+			b, err := bson.Marshal(bson.M{
+				"organization": "org",
+				"__v":          revision,
+				"tags":         tags,
+				"settings":     settings,
+			})
+			if err != nil {
+				return err
+			}
+
+			return bson.Unmarshal(b, dst)
+		},
+	}
+}
+
+func TestTagCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc organizationTags
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(organizationTags)
+			return nil
+		},
+	}
+
+	err := UpdateTag(context.TODO(), Tag{Organization: "org", ID: "checkout", Color: "#ff0000"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.Organization).To(Equal("org"))
+	g.Expect(insertedDoc.Tags).To(Equal([]string{"checkout"}))
+	g.Expect(insertedDoc.Settings).To(Equal(map[string]Tag{
+		"checkout": {Color: "#ff0000"},
+	}))
+}
+
+func TestTagUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter bson.M
+	var updateDoc bson.D
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return tagsResult(3, []string{"ui", "checkout"}, bson.M{
+				"ui":       bson.M{"color": "#000000", "description": "managed in the ui"},
+				"checkout": bson.M{"color": "#00ff00", "description": "old"},
+			}), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter.(bson.M)
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := UpdateTag(context.TODO(), Tag{Organization: "org", ID: "checkout", Color: "#ff0000", Description: "new"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(updateFilter).To(Equal(bson.M{"organization": "org", "__v": 3}))
+
+	set := updateDoc[0].Value.(bson.M)
+	g.Expect(set["__v"]).To(Equal(4))
+	g.Expect(set["tags"]).To(Equal([]string{"ui", "checkout"}))
+	g.Expect(set["settings"]).To(Equal(map[string]Tag{
+		"ui":       {Color: "#000000", Description: "managed in the ui"},
+		"checkout": {Color: "#ff0000", Description: "new"},
+	}))
+}
+
+func TestTagAdd(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateDoc bson.D
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return tagsResult(0, []string{"ui"}, nil), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := UpdateTag(context.TODO(), Tag{Organization: "org", ID: "checkout"}, db)
+	g.Expect(err).To(BeNil())
+
+	set := updateDoc[0].Value.(bson.M)
+	g.Expect(set["tags"]).To(Equal([]string{"ui", "checkout"}))
+	g.Expect(set["settings"]).To(Equal(map[string]Tag{
+		"checkout": {},
+	}))
+}
+
+func TestTagNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return tagsResult(1, []string{"checkout"}, bson.M{
+				"checkout": bson.M{"color": "#ff0000", "description": ""},
+			}), nil
+		},
+	}
+
+	err := UpdateTag(context.TODO(), Tag{Organization: "org", ID: "checkout", Color: "#ff0000"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestTagUpdateConflictExceeded(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return tagsResult(1, []string{}, nil), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			return storage.ErrNoMatch
+		},
+	}
+
+	err := UpdateTag(context.TODO(), Tag{Organization: "org", ID: "checkout"}, db)
+	g.Expect(errors.Is(err, ErrConflict)).To(BeTrue())
+}
+
+func TestTagDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateDoc bson.D
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return tagsResult(1, []string{"ui", "checkout"}, bson.M{
+				"checkout": bson.M{"color": "#ff0000", "description": ""},
+			}), nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateDoc = doc.(bson.D)
+			return nil
+		},
+	}
+
+	err := DeleteTag(context.TODO(), Tag{Organization: "org", ID: "checkout"}, db)
+	g.Expect(err).To(BeNil())
+
+	set := updateDoc[0].Value.(bson.M)
+	g.Expect(set["tags"]).To(Equal([]string{"ui"}))
+	g.Expect(set["settings"]).To(Equal(map[string]Tag{}))
+}
+
+func TestTagDeleteNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+	}
+
+	g.Expect(DeleteTag(context.TODO(), Tag{Organization: "org", ID: "checkout"}, db)).To(BeNil())
+}
+
+func TestTagDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return tagsResult(1, []string{"checkout"}, bson.M{
+				"checkout": bson.M{"color": "#00ff00", "description": ""},
+			}), nil
+		},
+	}
+
+	fields, err := DiffTag(context.TODO(), Tag{Organization: "org", ID: "checkout", Color: "#ff0000"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"color"}))
+
+	fields, err = DiffTag(context.TODO(), Tag{Organization: "org", ID: "other"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(BeEmpty())
+}
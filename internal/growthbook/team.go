@@ -0,0 +1,118 @@
+package growthbook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// TeamMemberRole is the role of organization members which are only added to the organization by a team,
+// their permissions are granted by their teams
+const TeamMemberRole = "noaccess"
+
+type Team struct {
+	ID                       string            `bson:"id"`
+	Organization             string            `bson:"organization"`
+	Name                     string            `bson:"name"`
+	Description              string            `bson:"description"`
+	Role                     string            `bson:"role"`
+	LimitAccessByEnvironment bool              `bson:"limitAccessByEnvironment"`
+	Environments             []string          `bson:"environments"`
+	ProjectRoles             []TeamProjectRole `bson:"projectRoles"`
+	DateCreated              time.Time         `bson:"dateCreated"`
+	DateUpdated              time.Time         `bson:"dateUpdated"`
+	Revision                 int               `bson:"__v"`
+}
+
+type TeamProjectRole struct {
+	Project                  string   `bson:"project"`
+	Role                     string   `bson:"role"`
+	LimitAccessByEnvironment bool     `bson:"limitAccessByEnvironment"`
+	Environments             []string `bson:"environments"`
+}
+
+func (t *Team) FromV1beta1(team v1beta1.GrowthbookTeam) *Team {
+	t.ID = team.GetID()
+	t.Name = team.GetName()
+	t.Description = team.Spec.Description
+	t.Role = team.Spec.Role
+	t.LimitAccessByEnvironment = len(team.Spec.Environments) > 0
+	t.Environments = append([]string{}, team.Spec.Environments...)
+	t.ProjectRoles = []TeamProjectRole{}
+
+	// Projects are referenced by resource name and need to be resolved by the caller
+	for _, projectRole := range team.Spec.ProjectRoles {
+		t.ProjectRoles = append(t.ProjectRoles, TeamProjectRole{
+			Project:                  projectRole.Project,
+			Role:                     projectRole.Role,
+			LimitAccessByEnvironment: len(projectRole.Environments) > 0,
+			Environments:             append([]string{}, projectRole.Environments...),
+		})
+	}
+
+	return t
+}
+
+func DeleteTeam(ctx context.Context, team Team, db storage.Database) error {
+	col := db.Collection("teams")
+	filter := bson.M{
+		"id":           team.ID,
+		"organization": team.Organization,
+	}
+
+	return col.DeleteOne(ctx, filter)
+}
+
+func UpdateTeam(ctx context.Context, team Team, db storage.Database) error {
+	col := db.Collection("teams")
+	filter := bson.M{
+		"id":           team.ID,
+		"organization": team.Organization,
+	}
+
+	_, err := updateDocument(ctx, col, filter, team, documentUpdate[Team]{
+		name:  fmt.Sprintf("team %s in organization %s", team.ID, team.Organization),
+		merge: mergeFunc(mergeTeam),
+		create: func(team Team) (Team, error) {
+			team.DateCreated = time.Now()
+			team.DateUpdated = team.DateCreated
+			return team, nil
+		},
+	})
+
+	return err
+}
+
+func GetTeamMeta(ctx context.Context, team Team, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("teams"), bson.M{
+		"id":           team.ID,
+		"organization": team.Organization,
+	})
+}
+
+func DiffTeam(ctx context.Context, team Team, db storage.Database) ([]string, error) {
+	col := db.Collection("teams")
+	filter := bson.M{
+		"id":           team.ID,
+		"organization": team.Organization,
+	}
+
+	return diffDocument(ctx, col, filter, team, mergeFunc(mergeTeam))
+}
+
+func mergeTeam(existing, team Team) Team {
+	existing.ID = team.ID
+	existing.Organization = team.Organization
+	existing.Name = team.Name
+	existing.Description = team.Description
+	existing.Role = team.Role
+	existing.LimitAccessByEnvironment = team.LimitAccessByEnvironment
+	existing.Environments = team.Environments
+	existing.ProjectRoles = team.ProjectRoles
+
+	return existing
+}
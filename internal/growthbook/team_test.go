@@ -0,0 +1,183 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTeamFromV1beta1(t *testing.T) {
+	g := NewWithT(t)
+
+	apiSpec := v1beta1.GrowthbookTeam{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: "bar",
+		},
+		Spec: v1beta1.GrowthbookTeamSpec{
+			Description: "foo",
+			Role:        "engineer",
+			ProjectRoles: []v1beta1.GrowthbookTeamProjectRole{
+				{
+					Project:      "frontend",
+					Role:         "admin",
+					Environments: []string{"staging"},
+				},
+			},
+		},
+	}
+
+	team := &Team{}
+	team.FromV1beta1(apiSpec)
+	g.Expect(team.ID).To(Equal(apiSpec.Name))
+	g.Expect(team.Name).To(Equal(apiSpec.Name))
+	g.Expect(team.Description).To(Equal(apiSpec.Spec.Description))
+	g.Expect(team.Role).To(Equal("engineer"))
+	g.Expect(team.LimitAccessByEnvironment).To(BeFalse())
+	g.Expect(team.Environments).To(Equal([]string{}))
+	g.Expect(team.ProjectRoles).To(Equal([]TeamProjectRole{
+		{
+			Project:                  "frontend",
+			Role:                     "admin",
+			LimitAccessByEnvironment: true,
+			Environments:             []string{"staging"},
+		},
+	}))
+
+	apiSpec.Spec.ID = "custom"
+	apiSpec.Spec.Name = "custom"
+	apiSpec.Spec.Environments = []string{"production"}
+	team.FromV1beta1(apiSpec)
+	g.Expect(team.ID).To(Equal(apiSpec.Spec.ID))
+	g.Expect(team.Name).To(Equal(apiSpec.Spec.Name))
+	g.Expect(team.LimitAccessByEnvironment).To(BeTrue())
+	g.Expect(team.Environments).To(Equal([]string{"production"}))
+}
+
+func TestTeamDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	var deleteFilter bson.M
+	db := &MockDatabase{
+		DeleteOne: func(ctx context.Context, filter interface{}) error {
+			deleteFilter = filter.(bson.M)
+			return nil
+		},
+	}
+
+	err := DeleteTeam(context.TODO(), Team{ID: "team", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(deleteFilter).To(Equal(bson.M{
+		"id":           "team",
+		"organization": "org",
+	}))
+}
+
+func TestTeamCreateIfNotExists(t *testing.T) {
+	g := NewWithT(t)
+
+	var insertedDoc Team
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return nil, mongo.ErrNoDocuments
+		},
+		InsertOne: func(ctx context.Context, doc interface{}) error {
+			insertedDoc = doc.(Team)
+			return nil
+		},
+	}
+
+	err := UpdateTeam(context.TODO(), Team{ID: "team", Organization: "org"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(insertedDoc.ID).To(Equal("team"))
+	g.Expect(insertedDoc.DateCreated).NotTo(BeZero())
+}
+
+func TestTeamNoUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Team).ID = "team"
+					dst.(*Team).Organization = "org"
+					dst.(*Team).Role = "engineer"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	err := UpdateTeam(context.TODO(), Team{ID: "team", Organization: "org", Role: "engineer"}, db)
+	g.Expect(err).To(BeNil())
+}
+
+func TestTeamUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	var updateFilter interface{}
+	var updateDoc interface{}
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Team).ID = "team"
+					dst.(*Team).Organization = "org"
+					dst.(*Team).Role = "readonly"
+					dst.(*Team).Revision = 2
+					return nil
+				},
+			}, nil
+		},
+		UpdateOne: func(ctx context.Context, filter, doc interface{}) error {
+			updateFilter = filter
+			updateDoc = doc
+			return nil
+		},
+	}
+
+	beforeUpdate := time.Now().Add(time.Duration(-1) * time.Hour)
+	err := UpdateTeam(context.TODO(), Team{ID: "team", Organization: "org", Role: "engineer"}, db)
+	g.Expect(err).To(BeNil())
+
+	updateBSON := updateDoc.(primitive.D)[0].Value.(bson.Raw)
+	g.Expect(updateBSON.Lookup("role").StringValue()).To(Equal("engineer"))
+	g.Expect(updateBSON.Lookup("dateUpdated").Time().After(beforeUpdate)).To(BeTrue())
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
+	g.Expect(updateFilter).To(Equal(bson.M{
+		"id":           "team",
+		"organization": "org",
+		"__v":          2,
+	}))
+}
+
+func TestTeamDiff(t *testing.T) {
+	g := NewWithT(t)
+
+	db := &MockDatabase{
+		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
+			return &MockResult{
+				decode: func(dst interface{}) error {
+					dst.(*Team).ID = "team"
+					dst.(*Team).Organization = "org"
+					dst.(*Team).Role = "admin"
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	fields, err := DiffTeam(context.TODO(), Team{ID: "team", Organization: "org", Role: "engineer"}, db)
+	g.Expect(err).To(BeNil())
+	g.Expect(fields).To(Equal([]string{"role"}))
+}
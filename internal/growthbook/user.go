@@ -1,7 +1,6 @@
 package growthbook
 
 import (
-	"bytes"
 	"context"
 	"crypto/rand"
 	"encoding/hex"
@@ -40,6 +39,10 @@ func (u *User) SetPassword(ctx context.Context, db storage.Database, password st
 	}
 
 	result, errFind := col.FindOne(ctx, filter)
+	if errFind != nil && !isNotFound(errFind) {
+		return errFind
+	}
+
 	var salt string
 
 	var existing User
@@ -88,38 +91,46 @@ func UpdateUser(ctx context.Context, user User, db storage.Database) error {
 		"id": user.ID,
 	}
 
-	var existing User
-	result, err := col.FindOne(ctx, filter)
+	_, err := updateDocument(ctx, col, filter, user, documentUpdate[User]{
+		name:  fmt.Sprintf("user %s", user.ID),
+		merge: mergeFunc(mergeUser),
+	})
 
+	return err
+}
+
+// FindUserByEmail returns the growthbook user with the given email
+func FindUserByEmail(ctx context.Context, email string, db storage.Database) (User, error) {
+	var user User
+	result, err := db.Collection("users").FindOne(ctx, bson.M{
+		"email": email,
+	})
 	if err != nil {
-		return col.InsertOne(ctx, user)
+		return user, err
 	}
 
-	if err := result.Decode(&existing); err != nil {
-		return err
-	}
+	return user, result.Decode(&user)
+}
 
-	existingBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
+func GetUserMeta(ctx context.Context, user User, db storage.Database) (DocumentMeta, error) {
+	return findDocumentMeta(ctx, db.Collection("users"), bson.M{
+		"id": user.ID,
+	})
+}
+
+func DiffUser(ctx context.Context, user User, db storage.Database) ([]string, error) {
+	col := db.Collection("users")
+	filter := bson.M{
+		"id": user.ID,
 	}
 
+	return diffDocument(ctx, col, filter, user, mergeFunc(mergeUser))
+}
+
+func mergeUser(existing, user User) User {
 	existing.ID = user.ID
 	existing.Email = user.Email
 	existing.Name = user.Name
 
-	updateBson, err := bson.Marshal(existing)
-	if err != nil {
-		return err
-	}
-
-	if bytes.Equal(existingBson, updateBson) {
-		return nil
-	}
-
-	update := bson.D{
-		{Key: "$set", Value: bson.Raw(updateBson)},
-	}
-
-	return col.UpdateOne(ctx, filter, update)
+	return existing
 }
@@ -2,7 +2,6 @@ package growthbook
 
 import (
 	"context"
-	"errors"
 	"testing"
 
 	"github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
@@ -10,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -67,7 +67,7 @@ func TestUserCreateIfNotExists(t *testing.T) {
 	var insertedDoc User
 	db := &MockDatabase{
 		FindOne: func(ctx context.Context, filter interface{}) (storage.Decoder, error) {
-			return nil, errors.New("does not exists")
+			return nil, mongo.ErrNoDocuments
 		},
 		InsertOne: func(ctx context.Context, doc interface{}) error {
 			insertedDoc = doc.(User)
@@ -118,6 +118,7 @@ func TestUserUpdate(t *testing.T) {
 				decode: func(dst interface{}) error {
 					dst.(*User).ID = "id"
 					dst.(*User).Email = "old@org.com"
+					dst.(*User).Revision = 2
 					return nil
 				},
 			}, nil
@@ -136,7 +137,8 @@ func TestUserUpdate(t *testing.T) {
 
 	expectedDoc, _ := bson.Marshal(user)
 	expectedFilter := bson.M{
-		"id": user.ID,
+		"id":  user.ID,
+		"__v": 2,
 	}
 
 	err := UpdateUser(context.TODO(), user, db)
@@ -147,6 +149,7 @@ func TestUserUpdate(t *testing.T) {
 	newEmailValue := updateBSON.Lookup("email")
 
 	g.Expect(newEmailValue).To(Equal(bson.Raw(expectedDoc).Lookup("email")))
+	g.Expect(updateBSON.Lookup("__v").Int32()).To(Equal(int32(3)))
 	g.Expect(updateFilter).To(Equal(expectedFilter))
 }
 
@@ -0,0 +1,96 @@
+package growthbook
+
+import (
+	"context"
+
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// SDKWebhook is called by growthbook whenever the payload of an sdk connection changes
+type SDKWebhook struct {
+	ID           string   `bson:"id"`
+	Organization string   `bson:"organization"`
+	Name         string   `bson:"name"`
+	Endpoint     string   `bson:"endpoint"`
+	SigningKey   string   `bson:"signingKey"`
+	UseSDKMode   bool     `bson:"useSdkMode"`
+	SDKs         []string `bson:"sdks"`
+	Environment  string   `bson:"environment"`
+	HTTPMethod   string   `bson:"httpMethod"`
+	Headers      string   `bson:"headers"`
+	SendPayload  bool     `bson:"sendPayload"`
+}
+
+// Matches returns true if the webhook needs to be called for the given sdk connection
+func (w SDKWebhook) Matches(sdkconnection SDKConnection) bool {
+	if w.UseSDKMode {
+		for _, key := range w.SDKs {
+			if key == sdkconnection.Key {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return w.Environment == "" || w.Environment == sdkconnection.Environment
+}
+
+// EventWebhook is called by growthbook for the subscribed events
+type EventWebhook struct {
+	ID           string   `bson:"id"`
+	Organization string   `bson:"organizationId"`
+	Name         string   `bson:"name"`
+	URL          string   `bson:"url"`
+	Events       []string `bson:"events"`
+	Enabled      bool     `bson:"enabled"`
+	SigningKey   string   `bson:"signingKey"`
+	Method       string   `bson:"method"`
+	Headers      string   `bson:"headers"`
+}
+
+// Subscribed returns true if the webhook is enabled and subscribed to the given event
+func (w EventWebhook) Subscribed(event FeatureEvent) bool {
+	if !w.Enabled {
+		return false
+	}
+
+	for _, e := range w.Events {
+		if e == string(event) || e == "*" || e == "feature.*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+func FindSDKWebhooks(ctx context.Context, organization string, db storage.Database) ([]SDKWebhook, error) {
+	col := db.Collection("webhooks")
+	filter := bson.M{
+		"organization": organization,
+	}
+
+	cursor, err := col.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []SDKWebhook
+	return webhooks, cursor.All(ctx, &webhooks)
+}
+
+func FindEventWebhooks(ctx context.Context, organization string, db storage.Database) ([]EventWebhook, error) {
+	col := db.Collection("eventwebhooks")
+	filter := bson.M{
+		"organizationId": organization,
+	}
+
+	cursor, err := col.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var webhooks []EventWebhook
+	return webhooks, cursor.All(ctx, &webhooks)
+}
@@ -0,0 +1,73 @@
+package growthbook
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	. "github.com/onsi/gomega"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+func TestSDKWebhookMatches(t *testing.T) {
+	g := NewWithT(t)
+
+	sdkconnection := SDKConnection{
+		Key:         "sdk-key",
+		Environment: "production",
+	}
+
+	g.Expect(SDKWebhook{UseSDKMode: true, SDKs: []string{"sdk-key"}}.Matches(sdkconnection)).To(BeTrue())
+	g.Expect(SDKWebhook{UseSDKMode: true, SDKs: []string{"other"}}.Matches(sdkconnection)).To(BeFalse())
+	g.Expect(SDKWebhook{Environment: "production"}.Matches(sdkconnection)).To(BeTrue())
+	g.Expect(SDKWebhook{Environment: "dev"}.Matches(sdkconnection)).To(BeFalse())
+	g.Expect(SDKWebhook{}.Matches(sdkconnection)).To(BeTrue())
+}
+
+func TestEventWebhookSubscribed(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(EventWebhook{Enabled: true, Events: []string{"feature.updated"}}.Subscribed(FeatureEventUpdated)).To(BeTrue())
+	g.Expect(EventWebhook{Enabled: true, Events: []string{"feature.created"}}.Subscribed(FeatureEventUpdated)).To(BeFalse())
+	g.Expect(EventWebhook{Enabled: true, Events: []string{"feature.*"}}.Subscribed(FeatureEventDeleted)).To(BeTrue())
+	g.Expect(EventWebhook{Events: []string{"feature.updated"}}.Subscribed(FeatureEventUpdated)).To(BeFalse())
+}
+
+func TestFindSDKWebhooks(t *testing.T) {
+	g := NewWithT(t)
+
+	var findFilter bson.M
+	db := &MockDatabase{
+		Find: func(ctx context.Context, filter interface{}) (storage.Cursor, error) {
+			findFilter = filter.(bson.M)
+			return &MockCursor{
+				all: func(results interface{}) error {
+					*results.(*[]SDKWebhook) = []SDKWebhook{{ID: "webhook"}}
+					return nil
+				},
+			}, nil
+		},
+	}
+
+	webhooks, err := FindSDKWebhooks(context.TODO(), "org", db)
+	g.Expect(err).To(BeNil())
+	g.Expect(webhooks).To(Equal([]SDKWebhook{{ID: "webhook"}}))
+	g.Expect(findFilter).To(Equal(bson.M{"organization": "org"}))
+}
+
+func TestFindEventWebhooks(t *testing.T) {
+	g := NewWithT(t)
+
+	var findFilter bson.M
+	db := &MockDatabase{
+		Find: func(ctx context.Context, filter interface{}) (storage.Cursor, error) {
+			findFilter = filter.(bson.M)
+			return &MockCursor{}, nil
+		},
+	}
+
+	webhooks, err := FindEventWebhooks(context.TODO(), "org", db)
+	g.Expect(err).To(BeNil())
+	g.Expect(webhooks).To(BeEmpty())
+	g.Expect(findFilter).To(Equal(bson.M{"organizationId": "org"}))
+}
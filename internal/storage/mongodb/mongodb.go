@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/DoodleScheduling/growthbook-controller/internal/storage"
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
@@ -19,7 +20,8 @@ func New(db *mongo.Database) *Database {
 }
 
 type Database struct {
-	db *mongo.Database
+	db                    *mongo.Database
+	transactionsSupported *bool
 }
 
 func (d *Database) Collection(collName string) storage.Collection {
@@ -28,10 +30,61 @@ func (d *Database) Collection(collName string) storage.Collection {
 	}
 }
 
+func (d *Database) WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	supported, err := d.supportsTransactions(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !supported {
+		return fn(ctx)
+	}
+
+	session, err := d.db.Client().StartSession()
+	if err != nil {
+		return err
+	}
+
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessCtx mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessCtx)
+	})
+
+	return err
+}
+
+// supportsTransactions returns true if the server is a replica set member or a sharded cluster.
+// Standalone servers do not support transactions.
+// The topology is only cached once the server has answered.
+func (d *Database) supportsTransactions(ctx context.Context) (bool, error) {
+	if d.transactionsSupported != nil {
+		return *d.transactionsSupported, nil
+	}
+
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+
+	err := d.db.Client().Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello)
+	if err != nil {
+		return false, err
+	}
+
+	supported := hello.SetName != "" || hello.Msg == "isdbgrid"
+	d.transactionsSupported = &supported
+	return supported, nil
+}
+
 type Collection struct {
 	collection *mongo.Collection
 }
 
+func (c *Collection) Find(ctx context.Context, filter interface{}) (storage.Cursor, error) {
+	return c.collection.Find(ctx, filter)
+}
+
 func (c *Collection) FindOne(ctx context.Context, filter interface{}) (storage.Decoder, error) {
 	res := c.collection.FindOne(ctx, filter)
 	return res, res.Err()
@@ -48,8 +101,16 @@ func (c *Collection) InsertOne(ctx context.Context, doc interface{}) error {
 }
 
 func (c *Collection) UpdateOne(ctx context.Context, filter interface{}, doc interface{}) error {
-	_, err := c.collection.UpdateOne(ctx, filter, doc)
-	return err
+	res, err := c.collection.UpdateOne(ctx, filter, doc)
+	if err != nil {
+		return err
+	}
+
+	if res.MatchedCount == 0 {
+		return storage.ErrNoMatch
+	}
+
+	return nil
 }
 
 func (c *Collection) DeleteMany(ctx context.Context, filter interface{}) error {
@@ -1,6 +1,12 @@
 package storage
 
-import "context"
+import (
+	"context"
+	"errors"
+)
+
+// ErrNoMatch is returned by UpdateOne if no document matched the filter
+var ErrNoMatch = errors.New("no document matched the filter")
 
 type Disconnector interface {
 	Disconnect(ctx context.Context) error
@@ -8,9 +14,13 @@ type Disconnector interface {
 
 type Database interface {
 	Collection(collName string) Collection
+	// WithTransaction runs fn within a transaction if the database supports it, otherwise fn is called directly.
+	// Operations need to use the context passed to fn to be part of the transaction.
+	WithTransaction(ctx context.Context, fn func(ctx context.Context) error) error
 }
 
 type Collection interface {
+	Find(ctx context.Context, filter interface{}) (Cursor, error)
 	FindOne(ctx context.Context, filter interface{}) (Decoder, error)
 	DeleteOne(ctx context.Context, filter interface{}) error
 	InsertOne(ctx context.Context, doc interface{}) error
@@ -21,3 +31,7 @@ type Collection interface {
 type Decoder interface {
 	Decode(v interface{}) error
 }
+
+type Cursor interface {
+	All(ctx context.Context, results interface{}) error
+}
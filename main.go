@@ -18,11 +18,13 @@ package main
 
 import (
 	"fmt"
+	"net/http"
 	"os"
 	"time"
 
 	infrav1beta1 "github.com/DoodleScheduling/growthbook-controller/api/v1beta1"
 	"github.com/DoodleScheduling/growthbook-controller/internal/controllers"
+	"github.com/DoodleScheduling/growthbook-controller/internal/growthbook"
 	"github.com/fluxcd/pkg/runtime/client"
 	helper "github.com/fluxcd/pkg/runtime/controller"
 	"github.com/fluxcd/pkg/runtime/leaderelection"
@@ -59,6 +61,7 @@ var (
 	healthAddr              string
 	concurrent              int
 	gracefulShutdownTimeout time.Duration
+	notificationMaxRetries  int
 	clientOptions           client.Options
 	kubeConfigOpts          client.KubeConfigOptions
 	logOptions              logger.Options
@@ -76,6 +79,8 @@ func main() {
 		"The number of concurrent Pod reconciles.")
 	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 600*time.Second,
 		"The duration given to the reconciler to finish before forcibly stopping.")
+	flag.IntVar(&notificationMaxRetries, "notification-max-retries", 10,
+		"The number of retries for failed webhook and proxy notifications.")
 
 	clientOptions.BindFlags(flag.CommandLine)
 	logOptions.BindFlags(flag.CommandLine)
@@ -117,6 +122,20 @@ func main() {
 				&infrav1beta1.GrowthbookOrganization{}: {Label: watchSelector},
 				&infrav1beta1.GrowthbookFeature{}:      {Label: watchSelector},
 				&infrav1beta1.GrowthbookClient{}:       {Label: watchSelector},
+				&infrav1beta1.GrowthbookProject{}:      {Label: watchSelector},
+				&infrav1beta1.GrowthbookSavedGroup{}:   {Label: watchSelector},
+				&infrav1beta1.GrowthbookExperiment{}:   {Label: watchSelector},
+				&infrav1beta1.GrowthbookAttribute{}:    {Label: watchSelector},
+				&infrav1beta1.GrowthbookTag{}:          {Label: watchSelector},
+				&infrav1beta1.GrowthbookAPIKey{}:       {Label: watchSelector},
+				&infrav1beta1.GrowthbookTeam{}:         {Label: watchSelector},
+				&infrav1beta1.GrowthbookArchetype{}:    {Label: watchSelector},
+				&infrav1beta1.GrowthbookDataSource{}:   {Label: watchSelector},
+				&infrav1beta1.GrowthbookMetric{}:       {Label: watchSelector},
+				&infrav1beta1.GrowthbookFactTable{}:    {Label: watchSelector},
+				&infrav1beta1.GrowthbookFactMetric{}:   {Label: watchSelector},
+				&infrav1beta1.GrowthbookSegment{}:      {Label: watchSelector},
+				&infrav1beta1.GrowthbookDimension{}:    {Label: watchSelector},
 			},
 		},
 	}
@@ -146,12 +165,30 @@ func main() {
 		os.Exit(1)
 	}
 
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+
+	notifier := growthbook.NewNotifier(ctrl.Log.WithName("notifier"), growthbook.NotifierOptions{
+		Client:     httpClient,
+		MaxRetries: notificationMaxRetries,
+		BaseDelay:  time.Second,
+		MaxDelay:   5 * time.Minute,
+	})
+
+	if err := mgr.Add(notifier); err != nil {
+		setupLog.Error(err, "unable to add notifier")
+		os.Exit(1)
+	}
+
 	reconciler := &controllers.GrowthbookInstanceReconciler{
 		Client:           mgr.GetClient(),
 		Log:              ctrl.Log.WithName("controllers").WithName("GrowthbookInstance"),
 		Scheme:           mgr.GetScheme(),
 		Recorder:         mgr.GetEventRecorderFor("GrowthbookInstance"),
 		DatabaseProvider: controllers.MongoDBProvider,
+		Notifier:         notifier,
+		HTTPClient:       httpClient,
 	}
 
 	if err = reconciler.SetupWithManager(mgr, controllers.GrowthbookInstanceReconcilerOptions{MaxConcurrentReconciles: concurrent}); err != nil {